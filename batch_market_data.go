@@ -0,0 +1,210 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// MaxTokensPerBatch is the number of tokens the server accepts per batch market data request
+	MaxTokensPerBatch = 500
+
+	// maxBatchConcurrency bounds the number of batch market data requests in flight
+	maxBatchConcurrency = 4
+)
+
+// fetchInBatches splits params into chunks of MaxTokensPerBatch and fetches
+// them with bounded concurrency. Results are returned in chunk order; the
+// first error cancels the chunks still in flight.
+func fetchInBatches[T any](
+	ctx context.Context,
+	params []BookParams,
+	fetch func(ctx context.Context, chunk []BookParams) (T, error),
+) ([]T, error) {
+	chunks := (len(params) + MaxTokensPerBatch - 1) / MaxTokensPerBatch
+	results := make([]T, chunks)
+
+	err := runBounded(ctx, chunks, maxBatchConcurrency, func(ctx context.Context, i int) error {
+		start := i * MaxTokensPerBatch
+		end := start + MaxTokensPerBatch
+		if end > len(params) {
+			end = len(params)
+		}
+
+		var err error
+		results[i], err = fetch(ctx, params[start:end])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// postMarketData posts a batch market data request. These requests only read
+// data, so they are retried like GET requests.
+func (c *ClobClient) postMarketData(ctx context.Context, endpoint string, params []BookParams, result interface{}) error {
+	resp, err := c.HTTPClient.request(ctx, http.MethodPost, c.Host+endpoint, nil, params, true)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrderBooks retrieves the order books of several tokens, keyed by token ID
+func (c *ClobClient) GetOrderBooks(params []BookParams) (map[string]*OrderBookSummary, error) {
+	return c.GetOrderBooksWithContext(context.Background(), params)
+}
+
+// GetOrderBooksWithContext is like GetOrderBooks but bound to ctx
+func (c *ClobClient) GetOrderBooksWithContext(
+	ctx context.Context,
+	params []BookParams,
+) (map[string]*OrderBookSummary, error) {
+	chunks, err := fetchInBatches(ctx, params, func(ctx context.Context, chunk []BookParams) ([]OrderBookSummary, error) {
+		var books []OrderBookSummary
+		err := c.postMarketData(ctx, EndpointGetOrderBooks, chunk, &books)
+		return books, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order books: %w", err)
+	}
+
+	books := make(map[string]*OrderBookSummary, len(params))
+	for _, chunk := range chunks {
+		for i := range chunk {
+			books[chunk[i].AssetID] = &chunk[i]
+		}
+	}
+
+	return books, nil
+}
+
+// GetPrices retrieves the prices of several tokens, keyed by token ID and side
+func (c *ClobClient) GetPrices(params []BookParams) (map[string]map[Side]float64, error) {
+	return c.GetPricesWithContext(context.Background(), params)
+}
+
+// GetPricesWithContext is like GetPrices but bound to ctx
+func (c *ClobClient) GetPricesWithContext(
+	ctx context.Context,
+	params []BookParams,
+) (map[string]map[Side]float64, error) {
+	chunks, err := fetchInBatches(ctx, params, func(ctx context.Context, chunk []BookParams) (map[string]map[Side]string, error) {
+		var prices map[string]map[Side]string
+		err := c.postMarketData(ctx, EndpointGetPrices, chunk, &prices)
+		return prices, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", err)
+	}
+
+	prices := make(map[string]map[Side]float64, len(params))
+	for _, chunk := range chunks {
+		for tokenID, sides := range chunk {
+			if prices[tokenID] == nil {
+				prices[tokenID] = make(map[Side]float64, len(sides))
+			}
+			for side, value := range sides {
+				price, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse price value: %w", err)
+				}
+				prices[tokenID][side] = price
+			}
+		}
+	}
+
+	return prices, nil
+}
+
+// GetMidpoints retrieves the midpoint prices of several tokens, keyed by token ID
+func (c *ClobClient) GetMidpoints(params []BookParams) (map[string]float64, error) {
+	return c.GetMidpointsWithContext(context.Background(), params)
+}
+
+// GetMidpointsWithContext is like GetMidpoints but bound to ctx
+func (c *ClobClient) GetMidpointsWithContext(ctx context.Context, params []BookParams) (map[string]float64, error) {
+	mids, err := c.getTokenValues(ctx, EndpointGetMidpoints, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get midpoints: %w", err)
+	}
+	return mids, nil
+}
+
+// GetSpreads retrieves the bid-ask spreads of several tokens, keyed by token ID
+func (c *ClobClient) GetSpreads(params []BookParams) (map[string]float64, error) {
+	return c.GetSpreadsWithContext(context.Background(), params)
+}
+
+// GetSpreadsWithContext is like GetSpreads but bound to ctx
+func (c *ClobClient) GetSpreadsWithContext(ctx context.Context, params []BookParams) (map[string]float64, error) {
+	spreads, err := c.getTokenValues(ctx, EndpointGetSpreads, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spreads: %w", err)
+	}
+	return spreads, nil
+}
+
+// getTokenValues fetches an endpoint that answers with a decimal string per token ID
+func (c *ClobClient) getTokenValues(ctx context.Context, endpoint string, params []BookParams) (map[string]float64, error) {
+	chunks, err := fetchInBatches(ctx, params, func(ctx context.Context, chunk []BookParams) (map[string]string, error) {
+		var values map[string]string
+		err := c.postMarketData(ctx, endpoint, chunk, &values)
+		return values, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]float64, len(params))
+	for _, chunk := range chunks {
+		for tokenID, value := range chunk {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse value for token %s: %w", tokenID, err)
+			}
+			values[tokenID] = parsed
+		}
+	}
+
+	return values, nil
+}
+
+// GetLastTradesPrices retrieves the last trade price of several tokens, keyed by token ID
+func (c *ClobClient) GetLastTradesPrices(params []BookParams) (map[string]LastTradePrice, error) {
+	return c.GetLastTradesPricesWithContext(context.Background(), params)
+}
+
+// GetLastTradesPricesWithContext is like GetLastTradesPrices but bound to ctx
+func (c *ClobClient) GetLastTradesPricesWithContext(
+	ctx context.Context,
+	params []BookParams,
+) (map[string]LastTradePrice, error) {
+	chunks, err := fetchInBatches(ctx, params, func(ctx context.Context, chunk []BookParams) ([]LastTradePrice, error) {
+		var prices []LastTradePrice
+		err := c.postMarketData(ctx, EndpointGetLastTradesPrices, chunk, &prices)
+		return prices, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last trade prices: %w", err)
+	}
+
+	prices := make(map[string]LastTradePrice, len(params))
+	for _, chunk := range chunks {
+		for _, price := range chunk {
+			prices[price.TokenID] = price
+		}
+	}
+
+	return prices, nil
+}
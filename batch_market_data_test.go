@@ -0,0 +1,138 @@
+package clobclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testBookParams(n int) []BookParams {
+	params := make([]BookParams, n)
+	for i := range params {
+		params[i] = BookParams{TokenID: fmt.Sprintf("%d", i), Side: SideBuy}
+	}
+	return params
+}
+
+func TestGetMidpointsChunksWithBoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	var batchSizes []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, EndpointGetMidpoints, r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		var params []BookParams
+		assert.NoError(t, json.Unmarshal(body, &params))
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(params))
+		mu.Unlock()
+
+		mids := make(map[string]string, len(params))
+		for _, p := range params {
+			mids[p.TokenID] = "0." + p.TokenID
+		}
+		json.NewEncoder(w).Encode(mids)
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	client.SetRateLimiter(nil)
+
+	params := testBookParams(10*MaxTokensPerBatch + 1)
+	mids, err := client.GetMidpoints(params)
+	assert.NoError(t, err)
+	assert.Len(t, mids, len(params))
+	assert.Equal(t, 0.42, mids["42"])
+	assert.Len(t, batchSizes, 11)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(maxBatchConcurrency))
+}
+
+func TestBatchMarketDataIsRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"1":"0.02"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	spreads, err := client.GetSpreads(testBookParams(2)[1:])
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"1": 0.02}, spreads)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestBatchMarketDataReturnsFirstError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid token id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	_, err := client.GetPrices(testBookParams(3 * MaxTokensPerBatch))
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "invalid token id", apiErr.Message)
+}
+
+func TestGetOrderBooksPricesAndLastTrades(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointGetOrderBooks:
+			w.Write([]byte(`[{"asset_id":"1","bids":[{"price":"0.4","size":"10"}]},{"asset_id":"2"}]`))
+		case EndpointGetPrices:
+			w.Write([]byte(`{"1":{"BUY":"0.4","SELL":"0.6"},"2":{"BUY":"0.1"}}`))
+		case EndpointGetLastTradesPrices:
+			w.Write([]byte(`[{"token_id":"1","price":"0.5","side":"SELL"}]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	params := []BookParams{{TokenID: "1"}, {TokenID: "2"}}
+
+	books, err := client.GetOrderBooks(params)
+	assert.NoError(t, err)
+	assert.Len(t, books, 2)
+	assert.Equal(t, "0.4", books["1"].Bids[0].Price)
+
+	prices, err := client.GetPrices(params)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[Side]float64{
+		"1": {SideBuy: 0.4, SideSell: 0.6},
+		"2": {SideBuy: 0.1},
+	}, prices)
+
+	lastTrades, err := client.GetLastTradesPrices(params)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]LastTradePrice{"1": {TokenID: "1", Price: "0.5", Side: SideSell}}, lastTrades)
+}
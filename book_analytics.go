@@ -0,0 +1,236 @@
+package clobclient
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// AmountUnit tells whether an amount is counted in shares or in USDC
+type AmountUnit int
+
+const (
+	AmountShares   AmountUnit = iota // Number of outcome tokens
+	AmountNotional                   // USDC value
+)
+
+// FillResult describes how an order would fill against the current book.
+// BUY orders take from the asks, SELL orders from the bids.
+type FillResult struct {
+	Side Side
+	// Shares and Notional are the filled size in shares and USDC
+	Shares   float64
+	Notional float64
+	// AveragePrice is the volume-weighted average fill price
+	AveragePrice float64
+	// BestPrice is the price of the first level, WorstPrice of the last one touched
+	BestPrice  float64
+	WorstPrice float64
+	// Slippage is how much worse the average price is than the best price
+	Slippage float64
+	// PriceImpact is how much worse the worst price is than the best price
+	PriceImpact float64
+	// LevelsConsumed counts the levels touched, including a partially filled last one
+	LevelsConsumed int
+	// Leftover is the part of the requested amount the book could not fill,
+	// in the unit the amount was given in
+	Leftover float64
+	// Complete reports whether the whole amount was filled
+	Complete bool
+}
+
+// Depth is the liquidity available on one side of the book
+type Depth struct {
+	Shares   float64
+	Notional float64
+	Levels   int
+}
+
+// fill is the exact result of a simulated fill
+type fill struct {
+	shares   *big.Rat
+	notional *big.Rat
+	best     *big.Rat
+	worst    *big.Rat
+	leftover *big.Rat
+	levels   int
+}
+
+// levelsFor returns the levels an order of the given side takes from, best first
+func (b *OrderBookSummary) levelsFor(side Side) ([]bookLevel, error) {
+	if side == SideSell {
+		return b.bidLevels()
+	}
+	return b.askLevels()
+}
+
+// simulateFill walks the levels an order of the given side takes from until
+// amount is filled or the book is exhausted. Levels priced zero or less can't
+// be traded and are skipped.
+func (b *OrderBookSummary) simulateFill(side Side, amount *big.Rat, unit AmountUnit) (*fill, error) {
+	all, err := b.levelsFor(side)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]bookLevel, 0, len(all))
+	for _, level := range all {
+		if level.price.Sign() > 0 {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, ErrEmptyBook
+	}
+
+	result := &fill{
+		shares:   new(big.Rat),
+		notional: new(big.Rat),
+		best:     levels[0].price,
+		worst:    levels[0].price,
+		leftover: new(big.Rat).Set(amount),
+	}
+
+	for _, level := range levels {
+		if result.leftover.Sign() <= 0 {
+			break
+		}
+
+		available := level.size
+		if unit == AmountNotional {
+			available = new(big.Rat).Mul(level.size, level.price)
+		}
+
+		take := available
+		if result.leftover.Cmp(available) < 0 {
+			take = result.leftover
+		}
+
+		shares := take
+		if unit == AmountNotional {
+			shares = new(big.Rat).Quo(take, level.price)
+		}
+
+		result.shares.Add(result.shares, shares)
+		result.notional.Add(result.notional, new(big.Rat).Mul(shares, level.price))
+		result.leftover = new(big.Rat).Sub(result.leftover, take)
+		result.worst = level.price
+		result.levels++
+	}
+
+	return result, nil
+}
+
+// SimulateFill simulates an order of the given side for amount, counted in
+// unit, against the book. The book is not required to fill the whole amount;
+// check Complete and Leftover.
+func (b *OrderBookSummary) SimulateFill(side Side, amount float64, unit AmountUnit) (*FillResult, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	f, err := b.simulateFill(side, decimalFromFloat(amount), unit)
+	if err != nil {
+		return nil, err
+	}
+
+	average := new(big.Rat)
+	if f.shares.Sign() > 0 {
+		average.Quo(f.notional, f.shares)
+	}
+
+	// Differences are oriented so that a worse price is positive on both sides
+	slippage := new(big.Rat).Sub(average, f.best)
+	impact := new(big.Rat).Sub(f.worst, f.best)
+	if side == SideSell {
+		slippage.Neg(slippage)
+		impact.Neg(impact)
+	}
+
+	result := &FillResult{
+		Side:           side,
+		LevelsConsumed: f.levels,
+		Complete:       f.leftover.Sign() <= 0,
+	}
+	result.Shares, _ = f.shares.Float64()
+	result.Notional, _ = f.notional.Float64()
+	result.AveragePrice, _ = average.Float64()
+	result.BestPrice, _ = f.best.Float64()
+	result.WorstPrice, _ = f.worst.Float64()
+	result.Slippage, _ = slippage.Float64()
+	result.PriceImpact, _ = impact.Float64()
+	result.Leftover, _ = f.leftover.Float64()
+
+	return result, nil
+}
+
+// VWAP returns the volume-weighted average price an order of the given side
+// would get for amount, counted in unit. It fails with ErrInsufficientLiquidity
+// if the book cannot fill the whole amount.
+func (b *OrderBookSummary) VWAP(side Side, amount float64, unit AmountUnit) (float64, error) {
+	result, err := b.SimulateFill(side, amount, unit)
+	if err != nil {
+		return 0, err
+	}
+	if !result.Complete {
+		return 0, fmt.Errorf("%w: %v left unfilled", ErrInsufficientLiquidity, result.Leftover)
+	}
+	return result.AveragePrice, nil
+}
+
+// DepthInBand returns the liquidity an order of the given side can take at
+// prices between minPrice and maxPrice, inclusive
+func (b *OrderBookSummary) DepthInBand(side Side, minPrice float64, maxPrice float64) (*Depth, error) {
+	levels, err := b.levelsFor(side)
+	if err != nil {
+		return nil, err
+	}
+	return depthInBand(levels, decimalFromFloat(minPrice), decimalFromFloat(maxPrice)), nil
+}
+
+// DepthWithinTicks returns the liquidity an order of the given side can take
+// without moving the price more than ticks ticks away from the best price
+func (b *OrderBookSummary) DepthWithinTicks(side Side, ticks int) (*Depth, error) {
+	if ticks < 0 {
+		return nil, fmt.Errorf("ticks must not be negative")
+	}
+
+	tickSize, err := decimalFromString(string(b.TickSize))
+	if err != nil {
+		return nil, fmt.Errorf("invalid tick size: %w", err)
+	}
+
+	levels, err := b.levelsFor(side)
+	if err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return &Depth{}, nil
+	}
+
+	best := levels[0].price
+	offset := new(big.Rat).Mul(tickSize, big.NewRat(int64(ticks), 1))
+	if side == SideSell {
+		return depthInBand(levels, new(big.Rat).Sub(best, offset), best), nil
+	}
+	return depthInBand(levels, best, new(big.Rat).Add(best, offset)), nil
+}
+
+// depthInBand sums the levels priced between min and max, inclusive
+func depthInBand(levels []bookLevel, min *big.Rat, max *big.Rat) *Depth {
+	shares := new(big.Rat)
+	notional := new(big.Rat)
+	depth := &Depth{}
+
+	for _, level := range levels {
+		if level.price.Cmp(min) < 0 || level.price.Cmp(max) > 0 {
+			continue
+		}
+		shares.Add(shares, level.size)
+		notional.Add(notional, new(big.Rat).Mul(level.size, level.price))
+		depth.Levels++
+	}
+
+	depth.Shares, _ = shares.Float64()
+	depth.Notional, _ = notional.Float64()
+	return depth
+}
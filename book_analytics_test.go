@@ -0,0 +1,124 @@
+package clobclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateFill(t *testing.T) {
+	book := testBook()
+
+	// 20 @ 0.52 + 30 @ 0.53 = 26.3 USDC for 50 shares
+	buy, err := book.SimulateFill(SideBuy, 50, AmountShares)
+	assert.NoError(t, err)
+	assert.Equal(t, &FillResult{
+		Side:           SideBuy,
+		Shares:         50,
+		Notional:       26.3,
+		AveragePrice:   0.526,
+		BestPrice:      0.52,
+		WorstPrice:     0.53,
+		Slippage:       0.006,
+		PriceImpact:    0.01,
+		LevelsConsumed: 2,
+		Complete:       true,
+	}, buy)
+
+	// 20 @ 0.50 = 10 USDC, then 10 more USDC at 0.49
+	sell, err := book.SimulateFill(SideSell, 20, AmountNotional)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, sell.Notional)
+	assert.InDelta(t, 20+10/0.49, sell.Shares, 1e-9)
+	assert.Equal(t, 0.50, sell.BestPrice)
+	assert.Equal(t, 0.49, sell.WorstPrice)
+	assert.Equal(t, 0.01, sell.PriceImpact)
+	assert.True(t, sell.Slippage > 0 && sell.Slippage < 0.01)
+	assert.Equal(t, 2, sell.LevelsConsumed)
+
+	// The bids hold 170 shares
+	partial, err := book.SimulateFill(SideSell, 200, AmountShares)
+	assert.NoError(t, err)
+	assert.False(t, partial.Complete)
+	assert.Equal(t, 170.0, partial.Shares)
+	assert.Equal(t, 30.0, partial.Leftover)
+	assert.Equal(t, 3, partial.LevelsConsumed)
+	assert.Equal(t, 0.48, partial.WorstPrice)
+
+	book.Asks = nil
+	_, err = book.SimulateFill(SideBuy, 10, AmountShares)
+	assert.ErrorIs(t, err, ErrEmptyBook)
+	_, err = book.SimulateFill(SideSell, 0, AmountShares)
+	assert.Error(t, err)
+}
+
+func TestSimulateFillSkipsZeroPricedLevels(t *testing.T) {
+	book := &OrderBookSummary{
+		Bids: []OrderSummary{{Price: "0", Size: "100"}, {Price: "0.50", Size: "20"}},
+	}
+
+	notional, err := book.SimulateFill(SideSell, 20, AmountNotional)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, notional.Shares)
+	assert.Equal(t, 10.0, notional.Notional)
+	assert.Equal(t, 10.0, notional.Leftover)
+	assert.Equal(t, 0.50, notional.WorstPrice)
+	assert.Equal(t, 1, notional.LevelsConsumed)
+
+	shares, err := book.SimulateFill(SideSell, 50, AmountShares)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, shares.Shares)
+	assert.Equal(t, 30.0, shares.Leftover)
+
+	book.Bids = []OrderSummary{{Price: "0", Size: "100"}}
+	_, err = book.SimulateFill(SideSell, 20, AmountNotional)
+	assert.ErrorIs(t, err, ErrEmptyBook)
+}
+
+func TestVWAP(t *testing.T) {
+	book := testBook()
+
+	vwap, err := book.VWAP(SideBuy, 20, AmountShares)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.52, vwap)
+
+	vwap, err = book.VWAP(SideBuy, 26.3, AmountNotional)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.526, vwap)
+
+	_, err = book.VWAP(SideBuy, 1000, AmountNotional)
+	assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+}
+
+func TestDepth(t *testing.T) {
+	book := testBook()
+
+	tests := []struct {
+		name     string
+		side     Side
+		ticks    int
+		expected Depth
+	}{
+		{"BUY at best ask", SideBuy, 0, Depth{Shares: 20, Notional: 10.4, Levels: 1}},
+		{"BUY within 1 tick", SideBuy, 1, Depth{Shares: 70, Notional: 36.9, Levels: 2}},
+		{"BUY within 2 ticks", SideBuy, 2, Depth{Shares: 70, Notional: 36.9, Levels: 2}},
+		{"BUY within 3 ticks", SideBuy, 3, Depth{Shares: 170, Notional: 91.9, Levels: 3}},
+		{"SELL within 1 tick", SideSell, 1, Depth{Shares: 70, Notional: 34.5, Levels: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depth, err := book.DepthWithinTicks(tt.side, tt.ticks)
+			assert.NoError(t, err)
+			assert.Equal(t, &tt.expected, depth)
+		})
+	}
+
+	depth, err := book.DepthInBand(SideSell, 0.48, 0.49)
+	assert.NoError(t, err)
+	assert.Equal(t, &Depth{Shares: 150, Notional: 72.5, Levels: 2}, depth)
+
+	book.TickSize = ""
+	_, err = book.DepthWithinTicks(SideBuy, 1)
+	assert.Error(t, err)
+}
@@ -1,28 +1,58 @@
 package clobclient
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// ClobClient is the main client for interacting with the Polymarket CLOB API
+// ClobClient is the main client for interacting with the Polymarket CLOB API.
+//
+// A ClobClient is safe for concurrent use by multiple goroutines once
+// configured. The exported fields must not be modified after the client is
+// shared; credentials can be swapped at any time with SetCredentials and
+// SetBuilderCredentials.
 type ClobClient struct {
-	Host           string
-	ChainID        int
-	PrivateKey     string
-	Creds          *ApiKeyCreds
-	SignatureType  SignatureType
-	FunderAddress  *string
-	OrderBuilder   *OrderBuilder
-	HTTPClient     *HTTPClient
-	UseServerTime  bool
-	BuilderCreds   *BuilderApiKey
-	tickSizeCache  map[string]tickSizeCacheEntry
-	negRiskCache   map[string]negRiskCacheEntry
+	Host          string
+	ChainID       int
+	PrivateKey    string
+	SignatureType SignatureType
+	FunderAddress *string
+	OrderBuilder  *OrderBuilder
+	HTTPClient    *HTTPClient
+	UseServerTime bool
+
+	// Creds holds the credentials passed to NewClobClient.
+	//
+	// Deprecated: use Credentials and SetCredentials. Writing Creds while the
+	// client is in use is a data race, and it is ignored once SetCredentials
+	// has been called.
+	Creds *ApiKeyCreds
+
+	// Deprecated: use BuilderCredentials and SetBuilderCredentials. Writing
+	// BuilderCreds while the client is in use is a data race, and it is ignored
+	// once SetBuilderCredentials has been called.
+	BuilderCreds *BuilderApiKey
+
+	creds         atomic.Pointer[credentialsSlot[ApiKeyCreds]]
+	builderCreds  atomic.Pointer[credentialsSlot[BuilderApiKey]]
+	cacheMu       sync.RWMutex
+	tickSizeCache map[string]tickSizeCacheEntry
+	negRiskCache  map[string]negRiskCacheEntry
+}
+
+// credentialsSlot holds credentials set with SetCredentials or
+// SetBuilderCredentials. A nil slot means they were never set, and the
+// deprecated field is used; a slot holding nil means they were cleared.
+type credentialsSlot[T any] struct {
+	creds *T
 }
 
 type tickSizeCacheEntry struct {
@@ -37,6 +67,16 @@ type negRiskCacheEntry struct {
 
 const (
 	cacheTTL = 5 * time.Minute
+
+	// MaxOrdersPerBatch is the number of orders the server accepts per PostOrders request
+	MaxOrdersPerBatch = 15
+
+	// MaxCancelOrdersPerBatch is the number of order IDs the server accepts per CancelOrders request
+	MaxCancelOrdersPerBatch = 3000
+
+	// maxOrderConcurrency bounds the number of tokens resolved, and orders
+	// signed, at once by CreateAndPostOrders
+	maxOrderConcurrency = 8
 )
 
 // NewClobClient creates a new CLOB client
@@ -48,11 +88,10 @@ func NewClobClient(
 	signatureType SignatureType,
 	funderAddress *string,
 ) *ClobClient {
-	return &ClobClient{
+	client := &ClobClient{
 		Host:          host,
 		ChainID:       chainID,
 		PrivateKey:    privateKey,
-		Creds:         creds,
 		SignatureType: signatureType,
 		FunderAddress: funderAddress,
 		OrderBuilder: NewOrderBuilder(
@@ -63,49 +102,180 @@ func NewClobClient(
 		),
 		HTTPClient:    NewHTTPClient(30*time.Second, true),
 		UseServerTime: false,
+		Creds:         creds,
 		tickSizeCache: make(map[string]tickSizeCacheEntry),
 		negRiskCache:  make(map[string]negRiskCacheEntry),
 	}
+	return client
+}
+
+// Credentials returns the API credentials currently used for L2 authentication
+func (c *ClobClient) Credentials() *ApiKeyCreds {
+	if slot := c.creds.Load(); slot != nil {
+		return slot.creds
+	}
+	return c.Creds
+}
+
+// SetCredentials atomically replaces the API credentials; nil clears them.
+// Requests already in flight keep using the credentials they were signed with.
+func (c *ClobClient) SetCredentials(creds *ApiKeyCreds) {
+	c.creds.Store(&credentialsSlot[ApiKeyCreds]{creds: creds})
+}
+
+// BuilderCredentials returns the builder API key credentials, if any. Orders
+// posted while they are set also carry the builder headers.
+func (c *ClobClient) BuilderCredentials() *BuilderApiKey {
+	if slot := c.builderCreds.Load(); slot != nil {
+		return slot.creds
+	}
+	return c.BuilderCreds
+}
+
+// SetBuilderCredentials atomically replaces the builder API key credentials;
+// nil clears them
+func (c *ClobClient) SetBuilderCredentials(creds *BuilderApiKey) {
+	c.builderCreds.Store(&credentialsSlot[BuilderApiKey]{creds: creds})
+}
+
+// addBuilderHeaders signs a request with the builder credentials, if any, on
+// top of its L2 headers
+func (c *ClobClient) addBuilderHeaders(
+	headers map[string]string,
+	method string,
+	requestPath string,
+	body string,
+) (map[string]string, error) {
+	builderCreds := c.BuilderCredentials()
+	if builderCreds == nil {
+		return headers, nil
+	}
+
+	timestamp, err := strconv.ParseInt(headers["POLY_TIMESTAMP"], 10, 64)
+	if err != nil {
+		timestamp = time.Now().Unix()
+	}
+
+	headers, err = InjectBuilderHeaders(headers, builderCreds, method, requestPath, body, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create builder headers: %w", err)
+	}
+	return headers, nil
+}
+
+// cachedTickSize returns the cached tick size of a token if it has not expired
+func (c *ClobClient) cachedTickSize(tokenID string) (TickSize, bool) {
+	c.cacheMu.RLock()
+	defer c.cacheMu.RUnlock()
+
+	entry, ok := c.tickSizeCache[tokenID]
+	if !ok || time.Since(entry.timestamp) > cacheTTL {
+		return "", false
+	}
+	return entry.tickSize, true
+}
+
+// storeTickSize caches the tick size of a token
+func (c *ClobClient) storeTickSize(tokenID string, tickSize TickSize) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	c.tickSizeCache[tokenID] = tickSizeCacheEntry{tickSize: tickSize, timestamp: time.Now()}
+}
+
+// cachedNegRisk returns the cached neg-risk flag of a token if it has not expired
+func (c *ClobClient) cachedNegRisk(tokenID string) (bool, bool) {
+	c.cacheMu.RLock()
+	defer c.cacheMu.RUnlock()
+
+	entry, ok := c.negRiskCache[tokenID]
+	if !ok || time.Since(entry.timestamp) > cacheTTL {
+		return false, false
+	}
+	return entry.negRisk, true
+}
+
+// storeNegRisk caches the neg-risk flag of a token
+func (c *ClobClient) storeNegRisk(tokenID string, negRisk bool) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	c.negRiskCache[tokenID] = negRiskCacheEntry{negRisk: negRisk, timestamp: time.Now()}
+}
+
+// ClearCaches drops all cached tick sizes and neg-risk flags
+func (c *ClobClient) ClearCaches() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	c.tickSizeCache = make(map[string]tickSizeCacheEntry)
+	c.negRiskCache = make(map[string]negRiskCacheEntry)
+}
+
+// SetRetryPolicy configures how this client retries failed requests
+func (c *ClobClient) SetRetryPolicy(policy RetryPolicy) {
+	c.HTTPClient.SetRetryPolicy(policy)
+}
+
+// SetRateLimiter paces this client's requests through limiter; nil disables pacing
+func (c *ClobClient) SetRateLimiter(limiter *RateLimiter) {
+	c.HTTPClient.SetRateLimiter(limiter)
 }
 
 // API Endpoints
 const (
-	EndpointTime                = "/time"
-	EndpointCreateAPIKey        = "/auth/api-key"
-	EndpointDeriveAPIKey        = "/auth/derive-api-key"
-	EndpointDeleteAPIKey        = "/auth/api-key"
-	EndpointGetAPIKeys          = "/auth/api-keys"
-	EndpointCreateReadonlyAPIKey = "/auth/readonly-api-key"
-	EndpointPostOrder           = "/order"
-	EndpointCancelOrder         = "/order"
-	EndpointCancelAll           = "/cancel-all"
-	EndpointCancelMarketOrders  = "/cancel-market-orders"
-	EndpointCancelOrders        = "/cancel-orders"
-	EndpointGetOrder            = "/data/order"
-	EndpointGetOpenOrders       = "/data/orders"
-	EndpointGetTrades           = "/data/trades"
-	EndpointGetOrderBook        = "/book"
-	EndpointGetOrderBooks       = "/books"
-	EndpointGetMidpoint         = "/midpoint"
-	EndpointGetPrice            = "/price"
-	EndpointGetLastTradePrice   = "/last-trade-price"
-	EndpointGetMarket           = "/market"
-	EndpointGetMarkets          = "/markets"
-	EndpointGetPricesHistory    = "/prices-history"
-	EndpointGetNotifications    = "/notifications"
-	EndpointDropNotifications   = "/notifications"
-	EndpointGetBalanceAllowance = "/balance-allowance"
+	EndpointTime                   = "/time"
+	EndpointCreateAPIKey           = "/auth/api-key"
+	EndpointDeriveAPIKey           = "/auth/derive-api-key"
+	EndpointDeleteAPIKey           = "/auth/api-key"
+	EndpointGetAPIKeys             = "/auth/api-keys"
+	EndpointCreateReadonlyAPIKey   = "/auth/readonly-api-key"
+	EndpointPostOrder              = "/order"
+	EndpointPostOrders             = "/orders"
+	EndpointCancelOrder            = "/order"
+	EndpointCancelAll              = "/cancel-all"
+	EndpointCancelMarketOrders     = "/cancel-market-orders"
+	EndpointCancelOrders           = "/cancel-orders"
+	EndpointGetOrder               = "/data/order"
+	EndpointGetOpenOrders          = "/data/orders"
+	EndpointGetTrades              = "/data/trades"
+	EndpointGetOrderBook           = "/book"
+	EndpointGetOrderBooks          = "/books"
+	EndpointGetMidpoint            = "/midpoint"
+	EndpointGetTickSize            = "/tick-size"
+	EndpointGetNegRisk             = "/neg-risk"
+	EndpointGetPrice               = "/price"
+	EndpointGetPrices              = "/prices"
+	EndpointGetMidpoints           = "/midpoints"
+	EndpointGetSpreads             = "/spreads"
+	EndpointGetLastTradePrice      = "/last-trade-price"
+	EndpointGetLastTradesPrices    = "/last-trades-prices"
+	EndpointGetMarket              = "/market"
+	EndpointGetMarketByID          = "/markets/"
+	EndpointGetMarkets             = "/markets"
+	EndpointGetSamplingMarkets     = "/sampling-markets"
+	EndpointGetSimplifiedMarkets   = "/simplified-markets"
+	EndpointGetSamplingSimplified  = "/sampling-simplified-markets"
+	EndpointGetPricesHistory       = "/prices-history"
+	EndpointGetNotifications       = "/notifications"
+	EndpointDropNotifications      = "/notifications"
+	EndpointGetBalanceAllowance    = "/balance-allowance"
 	EndpointUpdateBalanceAllowance = "/balance-allowance"
-	EndpointGetOrderScoring     = "/order-scoring"
-	EndpointGetOrdersScoring    = "/orders-scoring"
-	EndpointClosedOnly          = "/closed-only"
+	EndpointGetOrderScoring        = "/order-scoring"
+	EndpointGetOrdersScoring       = "/orders-scoring"
+	EndpointClosedOnly             = "/closed-only"
 )
 
 // GetServerTime returns the server time
 func (c *ClobClient) GetServerTime() (int64, error) {
+	return c.GetServerTimeWithContext(context.Background())
+}
+
+// GetServerTimeWithContext is like GetServerTime but bound to ctx
+func (c *ClobClient) GetServerTimeWithContext(ctx context.Context) (int64, error) {
 	url := c.Host + EndpointTime
 
-	resp, err := c.HTTPClient.Get(url, nil)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get server time: %w", err)
 	}
@@ -123,6 +293,11 @@ func (c *ClobClient) GetServerTime() (int64, error) {
 
 // CreateAPIKey creates a new API key using L1 authentication
 func (c *ClobClient) CreateAPIKey(nonce string) (*ApiKeyCreds, error) {
+	return c.CreateAPIKeyWithContext(context.Background(), nonce)
+}
+
+// CreateAPIKeyWithContext is like CreateAPIKey but bound to ctx
+func (c *ClobClient) CreateAPIKeyWithContext(ctx context.Context, nonce string) (*ApiKeyCreds, error) {
 	url := c.Host + EndpointCreateAPIKey
 
 	// Create L1 headers
@@ -131,7 +306,7 @@ func (c *ClobClient) CreateAPIKey(nonce string) (*ApiKeyCreds, error) {
 		return nil, fmt.Errorf("failed to create L1 headers: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Post(url, headers, nil)
+	resp, err := c.HTTPClient.PostWithContext(ctx, url, headers, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API key: %w", err)
 	}
@@ -150,6 +325,11 @@ func (c *ClobClient) CreateAPIKey(nonce string) (*ApiKeyCreds, error) {
 
 // DeriveAPIKey derives an API key using L1 authentication
 func (c *ClobClient) DeriveAPIKey(nonce string) (*ApiKeyCreds, error) {
+	return c.DeriveAPIKeyWithContext(context.Background(), nonce)
+}
+
+// DeriveAPIKeyWithContext is like DeriveAPIKey but bound to ctx
+func (c *ClobClient) DeriveAPIKeyWithContext(ctx context.Context, nonce string) (*ApiKeyCreds, error) {
 	url := c.Host + EndpointDeriveAPIKey
 
 	// Create L1 headers
@@ -158,7 +338,7 @@ func (c *ClobClient) DeriveAPIKey(nonce string) (*ApiKeyCreds, error) {
 		return nil, fmt.Errorf("failed to create L1 headers: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Get(url, headers)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive API key: %w", err)
 	}
@@ -177,19 +357,37 @@ func (c *ClobClient) DeriveAPIKey(nonce string) (*ApiKeyCreds, error) {
 
 // CreateOrDeriveAPIKey creates or derives an API key
 func (c *ClobClient) CreateOrDeriveAPIKey(nonce string) (*ApiKeyCreds, error) {
+	return c.CreateOrDeriveAPIKeyWithContext(context.Background(), nonce)
+}
+
+// CreateOrDeriveAPIKeyWithContext is like CreateOrDeriveAPIKey but bound to ctx
+func (c *ClobClient) CreateOrDeriveAPIKeyWithContext(ctx context.Context, nonce string) (*ApiKeyCreds, error) {
 	// Try to derive first
-	creds, err := c.DeriveAPIKey(nonce)
+	creds, err := c.DeriveAPIKeyWithContext(ctx, nonce)
 	if err == nil {
 		return creds, nil
 	}
 
+	// Don't fall back to creating a key if the caller has given up
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
 	// If derive fails, create a new key
-	return c.CreateAPIKey(nonce)
+	return c.CreateAPIKeyWithContext(ctx, nonce)
 }
 
-// PostOrder posts a signed order to the exchange
+// PostOrder posts a signed order to the exchange. If the exchange rejects
+// the order, the error is an *OrderError matching one of the ErrOrder* reasons,
+// returned along with the response.
 func (c *ClobClient) PostOrder(args *PostOrderArgs) (*OrderResponse, error) {
-	if c.Creds == nil {
+	return c.PostOrderWithContext(context.Background(), args)
+}
+
+// PostOrderWithContext is like PostOrder but bound to ctx
+func (c *ClobClient) PostOrderWithContext(ctx context.Context, args *PostOrderArgs) (*OrderResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
 		return nil, fmt.Errorf("API credentials required for posting orders")
 	}
 
@@ -206,7 +404,7 @@ func (c *ClobClient) PostOrder(args *PostOrderArgs) (*OrderResponse, error) {
 	// Create L2 headers
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
+		creds,
 		http.MethodPost,
 		requestPath,
 		bodyStr,
@@ -214,10 +412,14 @@ func (c *ClobClient) PostOrder(args *PostOrderArgs) (*OrderResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
+	headers, err = c.addBuilderHeaders(headers, http.MethodPost, requestPath, bodyStr)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := c.HTTPClient.Post(url, headers, args)
+	resp, err := c.HTTPClient.PostWithContext(ctx, url, headers, args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to post order: %w", err)
+		return nil, fmt.Errorf("failed to post order: %w", asOrderError(err))
 	}
 
 	var result OrderResponse
@@ -225,20 +427,253 @@ func (c *ClobClient) PostOrder(args *PostOrderArgs) (*OrderResponse, error) {
 		return nil, fmt.Errorf("failed to parse order response: %w", err)
 	}
 
+	// The response is returned along with the rejection for inspection
+	if err := result.Err(); err != nil {
+		return &result, err
+	}
+
 	return &result, nil
 }
 
-// CreateOrder creates an order from user input
+// PostOrders posts signed orders in batches of up to MaxOrdersPerBatch, one
+// authenticated request per batch. The responses are aligned with args. If a
+// batch fails, the responses of the batches already posted are returned along
+// with the error. Orders rejected individually don't fail the batch; check
+// each response's Err.
+func (c *ClobClient) PostOrders(args []PostOrderArgs) ([]OrderResponse, error) {
+	return c.PostOrdersWithContext(context.Background(), args)
+}
+
+// PostOrdersWithContext is like PostOrders but bound to ctx
+func (c *ClobClient) PostOrdersWithContext(ctx context.Context, args []PostOrderArgs) ([]OrderResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
+		return nil, fmt.Errorf("API credentials required for posting orders")
+	}
+
+	responses := make([]OrderResponse, 0, len(args))
+	for start := 0; start < len(args); start += MaxOrdersPerBatch {
+		end := start + MaxOrdersPerBatch
+		if end > len(args) {
+			end = len(args)
+		}
+
+		batch, err := c.postOrderBatch(ctx, creds, args[start:end])
+		if err != nil {
+			return responses, fmt.Errorf("failed to post orders %d-%d: %w", start, end-1, err)
+		}
+		responses = append(responses, batch...)
+	}
+
+	return responses, nil
+}
+
+// postOrderBatch posts a single batch of orders
+func (c *ClobClient) postOrderBatch(
+	ctx context.Context,
+	creds *ApiKeyCreds,
+	args []PostOrderArgs,
+) ([]OrderResponse, error) {
+	url := c.Host + EndpointPostOrders
+	requestPath := EndpointPostOrders
+
+	bodyBytes, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal orders: %w", err)
+	}
+	bodyStr := string(bodyBytes)
+
+	headers, err := CreateL2Headers(
+		c.PrivateKey,
+		creds,
+		http.MethodPost,
+		requestPath,
+		bodyStr,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
+	}
+	headers, err = c.addBuilderHeaders(headers, http.MethodPost, requestPath, bodyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.PostWithContext(ctx, url, headers, args)
+	if err != nil {
+		return nil, asOrderError(err)
+	}
+
+	var result []OrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse order responses: %w", err)
+	}
+
+	if len(result) != len(args) {
+		return nil, fmt.Errorf("expected %d order responses, got %d", len(args), len(result))
+	}
+
+	return result, nil
+}
+
+// CreateOrder creates an order from user input. options may be nil: a missing
+// tick size or neg-risk flag is resolved from the market.
 func (c *ClobClient) CreateOrder(
 	userOrder *UserOrder,
 	options *CreateOrderOptions,
 ) (*SignedOrder, error) {
-	// Validate price
-	if err := ValidatePrice(userOrder.Price, options.TickSize); err != nil {
+	return c.CreateOrderWithContext(context.Background(), userOrder, options)
+}
+
+// CreateOrderWithContext is like CreateOrder but bound to ctx
+func (c *ClobClient) CreateOrderWithContext(
+	ctx context.Context,
+	userOrder *UserOrder,
+	options *CreateOrderOptions,
+) (*SignedOrder, error) {
+	// Fill in the tick size and neg-risk flag from the market if not supplied
+	resolved, marketTickSize, err := c.resolveOrderOptions(ctx, userOrder.TokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.buildOrder(userOrder, resolved, marketTickSize)
+}
+
+// buildOrder validates the price of an order against resolved options and the
+// market tick size, and signs it
+func (c *ClobClient) buildOrder(
+	userOrder *UserOrder,
+	resolved *CreateOrderOptions,
+	marketTickSize TickSize,
+) (*SignedOrder, error) {
+	if err := validateOrderPrice(userOrder.Price, resolved.TickSize, marketTickSize); err != nil {
+		return nil, err
+	}
+
+	return c.OrderBuilder.BuildOrder(userOrder, resolved)
+}
+
+// validateOrderPrice validates a price against the order's tick size and, when
+// it differs, the market's. The exchange only accepts prices on the market
+// tick, whatever tick size the order was built with.
+func validateOrderPrice(price float64, tickSize TickSize, marketTickSize TickSize) error {
+	if err := ValidatePrice(price, tickSize); err != nil {
+		return err
+	}
+	if tickSize != marketTickSize {
+		return ValidatePrice(price, marketTickSize)
+	}
+	return nil
+}
+
+// resolveOrderOptions returns a copy of options with a missing tick size or
+// neg-risk flag resolved from the market, along with the market tick size. A
+// user-supplied tick size may be finer than the market's, but not coarser.
+func (c *ClobClient) resolveOrderOptions(
+	ctx context.Context,
+	tokenID string,
+	options *CreateOrderOptions,
+) (*CreateOrderOptions, TickSize, error) {
+	tickSize, err := c.GetTickSizeWithContext(ctx, tokenID)
+	if err != nil {
+		return nil, "", err
+	}
+	market := CreateOrderOptions{TickSize: tickSize}
+
+	if options == nil || options.NegRisk == nil {
+		negRisk, err := c.GetNegRiskWithContext(ctx, tokenID)
+		if err != nil {
+			return nil, "", err
+		}
+		market.NegRisk = &negRisk
+	}
+
+	resolved, err := applyMarketOptions(options, &market)
+	if err != nil {
+		return nil, "", err
+	}
+	return resolved, tickSize, nil
+}
+
+// applyMarketOptions returns a copy of options with a missing tick size or
+// neg-risk flag taken from market, the options of the order's market
+func applyMarketOptions(options *CreateOrderOptions, market *CreateOrderOptions) (*CreateOrderOptions, error) {
+	var resolved CreateOrderOptions
+	if options != nil {
+		resolved = *options
+	}
+
+	if resolved.TickSize == "" {
+		resolved.TickSize = market.TickSize
+	} else if tickSizeCoarser(resolved.TickSize, market.TickSize) {
+		return nil, fmt.Errorf("invalid tick size %s, market tick size is %s", resolved.TickSize, market.TickSize)
+	}
+
+	if resolved.NegRisk == nil {
+		resolved.NegRisk = market.NegRisk
+	}
+
+	return &resolved, nil
+}
+
+// tickSizeCoarser reports whether tick size a is larger than b. Tick sizes are
+// compared numerically, so that "0.010" equals "0.01"; unparsable ones that
+// differ count as coarser.
+func tickSizeCoarser(a TickSize, b TickSize) bool {
+	af, errA := strconv.ParseFloat(string(a), 64)
+	bf, errB := strconv.ParseFloat(string(b), 64)
+	if errA != nil || errB != nil {
+		return a != b
+	}
+	return af > bf
+}
+
+// CreateMarketOrder creates a market order. If no price is given, the order
+// book is fetched and walked to find the price at which Amount (USDC for BUY,
+// shares for SELL) fills. FOK orders, the default, fail with
+// ErrInsufficientLiquidity when the book is too thin.
+func (c *ClobClient) CreateMarketOrder(
+	userMarketOrder *UserMarketOrder,
+	options *CreateOrderOptions,
+) (*SignedOrder, error) {
+	return c.CreateMarketOrderWithContext(context.Background(), userMarketOrder, options)
+}
+
+// CreateMarketOrderWithContext is like CreateMarketOrder but bound to ctx
+func (c *ClobClient) CreateMarketOrderWithContext(
+	ctx context.Context,
+	userMarketOrder *UserMarketOrder,
+	options *CreateOrderOptions,
+) (*SignedOrder, error) {
+	resolved, marketTickSize, err := c.resolveOrderOptions(ctx, userMarketOrder.TokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	orderType := OrderTypeFOK
+	if userMarketOrder.OrderType != nil {
+		orderType = *userMarketOrder.OrderType
+	}
+
+	order := *userMarketOrder
+	if order.Price == nil {
+		book, err := c.GetOrderBookWithContext(ctx, order.TokenID)
+		if err != nil {
+			return nil, err
+		}
+
+		price, err := calculateMarketPrice(book, order.Side, order.Amount, orderType)
+		if err != nil {
+			return nil, err
+		}
+		order.Price = &price
+	}
+
+	if err := validateOrderPrice(*order.Price, resolved.TickSize, marketTickSize); err != nil {
 		return nil, err
 	}
 
-	return c.OrderBuilder.BuildOrder(userOrder, options)
+	return c.OrderBuilder.BuildMarketOrder(&order, resolved)
 }
 
 // CreateAndPostOrder creates and posts an order in one call
@@ -246,9 +681,19 @@ func (c *ClobClient) CreateAndPostOrder(
 	userOrder *UserOrder,
 	options *CreateOrderOptions,
 	orderType OrderType,
+) (*OrderResponse, error) {
+	return c.CreateAndPostOrderWithContext(context.Background(), userOrder, options, orderType)
+}
+
+// CreateAndPostOrderWithContext is like CreateAndPostOrder but bound to ctx
+func (c *ClobClient) CreateAndPostOrderWithContext(
+	ctx context.Context,
+	userOrder *UserOrder,
+	options *CreateOrderOptions,
+	orderType OrderType,
 ) (*OrderResponse, error) {
 	// Create the order
-	signedOrder, err := c.CreateOrder(userOrder, options)
+	signedOrder, err := c.CreateOrderWithContext(ctx, userOrder, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -259,12 +704,134 @@ func (c *ClobClient) CreateAndPostOrder(
 		OrderType: orderType,
 	}
 
-	return c.PostOrder(args)
+	return c.PostOrderWithContext(ctx, args)
+}
+
+// CreateAndPostOrders resolves the market options once per token, signs all
+// orders concurrently, then posts them with PostOrders. Nothing is posted if
+// any order fails to build.
+func (c *ClobClient) CreateAndPostOrders(orders []BatchOrder) ([]OrderResponse, error) {
+	return c.CreateAndPostOrdersWithContext(context.Background(), orders)
+}
+
+// CreateAndPostOrdersWithContext is like CreateAndPostOrders but bound to ctx
+func (c *ClobClient) CreateAndPostOrdersWithContext(ctx context.Context, orders []BatchOrder) ([]OrderResponse, error) {
+	markets, err := c.resolveMarketOptions(ctx, orders)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]PostOrderArgs, len(orders))
+	err = runBounded(ctx, len(orders), maxOrderConcurrency, func(ctx context.Context, i int) error {
+		market := markets[orders[i].Order.TokenID]
+		resolved, err := applyMarketOptions(orders[i].Options, market)
+		if err != nil {
+			return fmt.Errorf("failed to create order %d: %w", i, err)
+		}
+
+		signedOrder, err := c.buildOrder(orders[i].Order, resolved, market.TickSize)
+		if err != nil {
+			return fmt.Errorf("failed to create order %d: %w", i, err)
+		}
+
+		args[i] = PostOrderArgs{
+			Order:     *signedOrder,
+			OrderType: orders[i].OrderType,
+			PostOnly:  orders[i].PostOnly,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PostOrdersWithContext(ctx, args)
+}
+
+// resolveMarketOptions fetches the tick size and neg-risk flag of every
+// distinct token of a batch, with bounded concurrency
+func (c *ClobClient) resolveMarketOptions(ctx context.Context, orders []BatchOrder) (map[string]*CreateOrderOptions, error) {
+	var tokenIDs []string
+	markets := make(map[string]*CreateOrderOptions)
+	for _, order := range orders {
+		if _, ok := markets[order.Order.TokenID]; !ok {
+			markets[order.Order.TokenID] = nil
+			tokenIDs = append(tokenIDs, order.Order.TokenID)
+		}
+	}
+
+	resolved := make([]*CreateOrderOptions, len(tokenIDs))
+	err := runBounded(ctx, len(tokenIDs), maxOrderConcurrency, func(ctx context.Context, i int) error {
+		var err error
+		resolved[i], _, err = c.resolveOrderOptions(ctx, tokenIDs[i], nil)
+		if err != nil {
+			return fmt.Errorf("failed to resolve options for token %s: %w", tokenIDs[i], err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tokenID := range tokenIDs {
+		markets[tokenID] = resolved[i]
+	}
+	return markets, nil
+}
+
+// runBounded calls fn for every index in [0, n), with at most limit calls in
+// flight. The first error cancels the context of the calls still running and
+// skips those not yet started.
+func runBounded(ctx context.Context, n int, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+
+			if errs[i] = fn(ctx, i); errs[i] != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Report the error that caused the cancellation rather than context.Canceled
+	var firstErr error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if firstErr == nil || (errors.Is(firstErr, context.Canceled) && !errors.Is(err, context.Canceled)) {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 // CancelOrder cancels an order by ID
-func (c *ClobClient) CancelOrder(orderID string) (*OrderResponse, error) {
-	if c.Creds == nil {
+func (c *ClobClient) CancelOrder(orderID string) (*CancelResponse, error) {
+	return c.CancelOrderWithContext(context.Background(), orderID)
+}
+
+// CancelOrderWithContext is like CancelOrder but bound to ctx
+func (c *ClobClient) CancelOrderWithContext(ctx context.Context, orderID string) (*CancelResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
 		return nil, fmt.Errorf("API credentials required for canceling orders")
 	}
 
@@ -280,7 +847,7 @@ func (c *ClobClient) CancelOrder(orderID string) (*OrderResponse, error) {
 
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
+		creds,
 		http.MethodDelete,
 		requestPath,
 		bodyStr,
@@ -289,140 +856,194 @@ func (c *ClobClient) CancelOrder(orderID string) (*OrderResponse, error) {
 		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Delete(url, headers, body)
+	resp, err := c.HTTPClient.DeleteWithContext(ctx, url, headers, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to cancel order: %w", err)
 	}
 
-	var result OrderResponse
+	var result CancelResponse
 	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse cancel response: %w", err)
 	}
 
 	return &result, nil
 }
 
-// CancelAll cancels all open orders
-func (c *ClobClient) CancelAll() error {
-	if c.Creds == nil {
-		return fmt.Errorf("API credentials required for canceling orders")
+// CancelOrders cancels orders by ID. Lists longer than MaxCancelOrdersPerBatch
+// are split into several requests; if one of them fails, the result of the
+// requests that succeeded is returned along with the error.
+func (c *ClobClient) CancelOrders(orderIDs []string) (*CancelResponse, error) {
+	return c.CancelOrdersWithContext(context.Background(), orderIDs)
+}
+
+// CancelOrdersWithContext is like CancelOrders but bound to ctx
+func (c *ClobClient) CancelOrdersWithContext(ctx context.Context, orderIDs []string) (*CancelResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
+		return nil, fmt.Errorf("API credentials required for canceling orders")
 	}
 
-	url := c.Host + EndpointCancelAll
-	requestPath := EndpointCancelAll
+	result := &CancelResponse{
+		Canceled:    []string{},
+		NotCanceled: map[string]string{},
+	}
+	for start := 0; start < len(orderIDs); start += MaxCancelOrdersPerBatch {
+		end := start + MaxCancelOrdersPerBatch
+		if end > len(orderIDs) {
+			end = len(orderIDs)
+		}
+
+		batch, err := c.cancelOrderBatch(ctx, creds, orderIDs[start:end])
+		if err != nil {
+			return result, fmt.Errorf("failed to cancel orders %d-%d: %w", start, end-1, err)
+		}
+		result.merge(batch)
+	}
+
+	return result, nil
+}
+
+// cancelOrderBatch cancels a single batch of orders
+func (c *ClobClient) cancelOrderBatch(
+	ctx context.Context,
+	creds *ApiKeyCreds,
+	orderIDs []string,
+) (*CancelResponse, error) {
+	url := c.Host + EndpointCancelOrders
+	requestPath := EndpointCancelOrders
+
+	bodyBytes, err := json.Marshal(orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal order IDs: %w", err)
+	}
+	bodyStr := string(bodyBytes)
 
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
+		creds,
 		http.MethodDelete,
 		requestPath,
-		"",
+		bodyStr,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create L2 headers: %w", err)
+		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
 
-	_, err = c.HTTPClient.Delete(url, headers, nil)
+	resp, err := c.HTTPClient.DeleteWithContext(ctx, url, headers, orderIDs)
 	if err != nil {
-		return fmt.Errorf("failed to cancel all orders: %w", err)
+		return nil, err
 	}
 
-	return nil
-}
-
-// CancelMarketOrders cancels all orders for a specific market or asset
-func (c *ClobClient) CancelMarketOrders(params *OrderMarketCancelParams) error {
-	if c.Creds == nil {
-		return fmt.Errorf("API credentials required for canceling orders")
+	var result CancelResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse cancel response: %w", err)
 	}
 
-	url := c.Host + EndpointCancelMarketOrders
-	requestPath := EndpointCancelMarketOrders
+	return &result, nil
+}
 
-	bodyBytes, err := json.Marshal(params)
-	if err != nil {
-		return fmt.Errorf("failed to marshal params: %w", err)
+// CancelAll cancels all open orders
+func (c *ClobClient) CancelAll() (*CancelResponse, error) {
+	return c.CancelAllWithContext(context.Background())
+}
+
+// CancelAllWithContext is like CancelAll but bound to ctx
+func (c *ClobClient) CancelAllWithContext(ctx context.Context) (*CancelResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
+		return nil, fmt.Errorf("API credentials required for canceling orders")
 	}
-	bodyStr := string(bodyBytes)
+
+	url := c.Host + EndpointCancelAll
+	requestPath := EndpointCancelAll
 
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
+		creds,
 		http.MethodDelete,
 		requestPath,
-		bodyStr,
+		"",
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create L2 headers: %w", err)
+		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
 
-	_, err = c.HTTPClient.Delete(url, headers, params)
+	resp, err := c.HTTPClient.DeleteWithContext(ctx, url, headers, nil)
 	if err != nil {
-		return fmt.Errorf("failed to cancel market orders: %w", err)
+		return nil, fmt.Errorf("failed to cancel all orders: %w", err)
 	}
 
-	return nil
+	var result CancelResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse cancel response: %w", err)
+	}
+
+	return &result, nil
 }
 
-// GetOpenOrders retrieves open orders
-func (c *ClobClient) GetOpenOrders(params *OpenOrderParams) ([]OpenOrder, error) {
-	if c.Creds == nil {
-		return nil, fmt.Errorf("API credentials required")
+// CancelMarketOrders cancels all orders for a specific market or asset
+func (c *ClobClient) CancelMarketOrders(params *OrderMarketCancelParams) (*CancelResponse, error) {
+	return c.CancelMarketOrdersWithContext(context.Background(), params)
+}
+
+// CancelMarketOrdersWithContext is like CancelMarketOrders but bound to ctx
+func (c *ClobClient) CancelMarketOrdersWithContext(ctx context.Context, params *OrderMarketCancelParams) (*CancelResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
+		return nil, fmt.Errorf("API credentials required for canceling orders")
 	}
 
-	url := c.Host + EndpointGetOpenOrders
-	requestPath := EndpointGetOpenOrders
+	url := c.Host + EndpointCancelMarketOrders
+	requestPath := EndpointCancelMarketOrders
 
-	// Build query parameters
-	queryParams := buildQueryParams(params)
-	if queryParams != "" {
-		url += "?" + queryParams
-		requestPath += "?" + queryParams
+	bodyBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params: %w", err)
 	}
+	bodyStr := string(bodyBytes)
 
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
-		http.MethodGet,
+		creds,
+		http.MethodDelete,
 		requestPath,
-		"",
+		bodyStr,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Get(url, headers)
+	resp, err := c.HTTPClient.DeleteWithContext(ctx, url, headers, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get open orders: %w", err)
+		return nil, fmt.Errorf("failed to cancel market orders: %w", err)
 	}
 
-	var orders []OpenOrder
-	if err := json.Unmarshal(resp, &orders); err != nil {
-		return nil, fmt.Errorf("failed to parse orders: %w", err)
+	var result CancelResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse cancel response: %w", err)
 	}
 
-	return orders, nil
+	return &result, nil
 }
 
-// GetTrades retrieves trades
-func (c *ClobClient) GetTrades(params *TradeParams) ([]Trade, error) {
-	if c.Creds == nil {
+// GetOrder retrieves a single order by ID
+func (c *ClobClient) GetOrder(orderID string) (*OpenOrder, error) {
+	return c.GetOrderWithContext(context.Background(), orderID)
+}
+
+// GetOrderWithContext is like GetOrder but bound to ctx
+func (c *ClobClient) GetOrderWithContext(ctx context.Context, orderID string) (*OpenOrder, error) {
+	creds := c.Credentials()
+	if creds == nil {
 		return nil, fmt.Errorf("API credentials required")
 	}
 
-	url := c.Host + EndpointGetTrades
-	requestPath := EndpointGetTrades
-
-	// Build query parameters
-	queryParams := buildQueryParams(params)
-	if queryParams != "" {
-		url += "?" + queryParams
-		requestPath += "?" + queryParams
-	}
+	requestPath := EndpointGetOrder + "/" + orderID
+	url := c.Host + requestPath
 
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
+		creds,
 		http.MethodGet,
 		requestPath,
 		"",
@@ -431,24 +1052,60 @@ func (c *ClobClient) GetTrades(params *TradeParams) ([]Trade, error) {
 		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Get(url, headers)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, headers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get trades: %w", err)
+		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 
-	var trades []Trade
-	if err := json.Unmarshal(resp, &trades); err != nil {
-		return nil, fmt.Errorf("failed to parse trades: %w", err)
+	var order OpenOrder
+	if err := json.Unmarshal(resp, &order); err != nil {
+		return nil, fmt.Errorf("failed to parse order: %w", err)
 	}
 
-	return trades, nil
+	return &order, nil
+}
+
+// GetOpenOrders retrieves every page of open orders matching params.
+//
+// Deprecated: use GetAllOpenOrders, OpenOrdersIterator or GetOpenOrdersPage.
+// GetOpenOrders used to return the first page only; it now delegates to
+// GetAllOpenOrders.
+func (c *ClobClient) GetOpenOrders(params *OpenOrderParams) ([]OpenOrder, error) {
+	return c.GetAllOpenOrdersWithContext(context.Background(), params)
+}
+
+// GetOpenOrdersWithContext is like GetOpenOrders but bound to ctx.
+//
+// Deprecated: use GetAllOpenOrdersWithContext.
+func (c *ClobClient) GetOpenOrdersWithContext(ctx context.Context, params *OpenOrderParams) ([]OpenOrder, error) {
+	return c.GetAllOpenOrdersWithContext(ctx, params)
+}
+
+// GetTrades retrieves every page of trades matching params.
+//
+// Deprecated: use GetAllTrades, TradesIterator or GetTradesPage. GetTrades
+// used to return the first page only; it now delegates to GetAllTrades.
+func (c *ClobClient) GetTrades(params *TradeParams) ([]Trade, error) {
+	return c.GetAllTradesWithContext(context.Background(), params)
+}
+
+// GetTradesWithContext is like GetTrades but bound to ctx.
+//
+// Deprecated: use GetAllTradesWithContext.
+func (c *ClobClient) GetTradesWithContext(ctx context.Context, params *TradeParams) ([]Trade, error) {
+	return c.GetAllTradesWithContext(ctx, params)
 }
 
 // GetOrderBook retrieves the order book for a token
 func (c *ClobClient) GetOrderBook(tokenID string) (*OrderBookSummary, error) {
+	return c.GetOrderBookWithContext(context.Background(), tokenID)
+}
+
+// GetOrderBookWithContext is like GetOrderBook but bound to ctx
+func (c *ClobClient) GetOrderBookWithContext(ctx context.Context, tokenID string) (*OrderBookSummary, error) {
 	url := fmt.Sprintf("%s%s?token_id=%s", c.Host, EndpointGetOrderBook, tokenID)
 
-	resp, err := c.HTTPClient.Get(url, nil)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order book: %w", err)
 	}
@@ -463,12 +1120,17 @@ func (c *ClobClient) GetOrderBook(tokenID string) (*OrderBookSummary, error) {
 
 // GetPrice retrieves the mid price for a token
 func (c *ClobClient) GetPrice(tokenID string, side *Side) (float64, error) {
+	return c.GetPriceWithContext(context.Background(), tokenID, side)
+}
+
+// GetPriceWithContext is like GetPrice but bound to ctx
+func (c *ClobClient) GetPriceWithContext(ctx context.Context, tokenID string, side *Side) (float64, error) {
 	url := fmt.Sprintf("%s%s?token_id=%s", c.Host, EndpointGetPrice, tokenID)
 	if side != nil {
 		url += "&side=" + string(*side)
 	}
 
-	resp, err := c.HTTPClient.Get(url, nil)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get price: %w", err)
 	}
@@ -491,9 +1153,14 @@ func (c *ClobClient) GetPrice(tokenID string, side *Side) (float64, error) {
 
 // GetMidpoint retrieves the midpoint price for a token
 func (c *ClobClient) GetMidpoint(tokenID string) (float64, error) {
+	return c.GetMidpointWithContext(context.Background(), tokenID)
+}
+
+// GetMidpointWithContext is like GetMidpoint but bound to ctx
+func (c *ClobClient) GetMidpointWithContext(ctx context.Context, tokenID string) (float64, error) {
 	url := fmt.Sprintf("%s%s?token_id=%s", c.Host, EndpointGetMidpoint, tokenID)
 
-	resp, err := c.HTTPClient.Get(url, nil)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get midpoint: %w", err)
 	}
@@ -514,9 +1181,105 @@ func (c *ClobClient) GetMidpoint(tokenID string) (float64, error) {
 	return mid, nil
 }
 
+// GetLastTradePrice retrieves the price and side of the last trade of a token
+func (c *ClobClient) GetLastTradePrice(tokenID string) (*LastTradePrice, error) {
+	return c.GetLastTradePriceWithContext(context.Background(), tokenID)
+}
+
+// GetLastTradePriceWithContext is like GetLastTradePrice but bound to ctx
+func (c *ClobClient) GetLastTradePriceWithContext(ctx context.Context, tokenID string) (*LastTradePrice, error) {
+	url := fmt.Sprintf("%s%s?token_id=%s", c.Host, EndpointGetLastTradePrice, tokenID)
+
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last trade price: %w", err)
+	}
+
+	var result LastTradePrice
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse last trade price: %w", err)
+	}
+	result.TokenID = tokenID
+
+	return &result, nil
+}
+
+// GetTickSize returns the minimum tick size of a token, cached for a few minutes
+func (c *ClobClient) GetTickSize(tokenID string) (TickSize, error) {
+	return c.GetTickSizeWithContext(context.Background(), tokenID)
+}
+
+// GetTickSizeWithContext is like GetTickSize but bound to ctx
+func (c *ClobClient) GetTickSizeWithContext(ctx context.Context, tokenID string) (TickSize, error) {
+	if tickSize, ok := c.cachedTickSize(tokenID); ok {
+		return tickSize, nil
+	}
+
+	url := fmt.Sprintf("%s%s?token_id=%s", c.Host, EndpointGetTickSize, tokenID)
+
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tick size: %w", err)
+	}
+
+	var result struct {
+		MinimumTickSize json.Number `json:"minimum_tick_size"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse tick size: %w", err)
+	}
+
+	if result.MinimumTickSize == "" {
+		return "", fmt.Errorf("tick size missing from response")
+	}
+
+	tickSize := TickSize(result.MinimumTickSize.String())
+	c.storeTickSize(tokenID, tickSize)
+
+	return tickSize, nil
+}
+
+// GetNegRisk reports whether a token belongs to a negative-risk market, cached for a few minutes
+func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
+	return c.GetNegRiskWithContext(context.Background(), tokenID)
+}
+
+// GetNegRiskWithContext is like GetNegRisk but bound to ctx
+func (c *ClobClient) GetNegRiskWithContext(ctx context.Context, tokenID string) (bool, error) {
+	if negRisk, ok := c.cachedNegRisk(tokenID); ok {
+		return negRisk, nil
+	}
+
+	url := fmt.Sprintf("%s%s?token_id=%s", c.Host, EndpointGetNegRisk, tokenID)
+
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get neg risk: %w", err)
+	}
+
+	var result struct {
+		NegRisk bool `json:"neg_risk"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return false, fmt.Errorf("failed to parse neg risk: %w", err)
+	}
+
+	c.storeNegRisk(tokenID, result.NegRisk)
+
+	return result.NegRisk, nil
+}
+
 // GetBalanceAllowance retrieves balance and allowance for an asset
 func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (*BalanceAllowanceResponse, error) {
-	if c.Creds == nil {
+	return c.GetBalanceAllowanceWithContext(context.Background(), params)
+}
+
+// GetBalanceAllowanceWithContext is like GetBalanceAllowance but bound to ctx
+func (c *ClobClient) GetBalanceAllowanceWithContext(ctx context.Context, params *BalanceAllowanceParams) (*BalanceAllowanceResponse, error) {
+	creds := c.Credentials()
+	if creds == nil {
 		return nil, fmt.Errorf("API credentials required")
 	}
 
@@ -531,7 +1294,7 @@ func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (*Balan
 
 	headers, err := CreateL2Headers(
 		c.PrivateKey,
-		c.Creds,
+		creds,
 		http.MethodGet,
 		requestPath,
 		"",
@@ -540,7 +1303,7 @@ func (c *ClobClient) GetBalanceAllowance(params *BalanceAllowanceParams) (*Balan
 		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Get(url, headers)
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance allowance: %w", err)
 	}
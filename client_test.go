@@ -1,6 +1,14 @@
 package clobclient
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,10 +70,10 @@ func TestGetRoundConfig(t *testing.T) {
 		tickSize TickSize
 		expected RoundConfig
 	}{
-		{TickSize01, RoundConfig{Price: 1, Size: 1, Amount: 1}},
-		{TickSize001, RoundConfig{Price: 2, Size: 2, Amount: 2}},
-		{TickSize0001, RoundConfig{Price: 3, Size: 3, Amount: 3}},
-		{TickSize00001, RoundConfig{Price: 4, Size: 4, Amount: 4}},
+		{TickSize01, RoundConfig{Price: 1, Size: 2, Amount: 3}},
+		{TickSize001, RoundConfig{Price: 2, Size: 2, Amount: 4}},
+		{TickSize0001, RoundConfig{Price: 3, Size: 2, Amount: 5}},
+		{TickSize00001, RoundConfig{Price: 4, Size: 2, Amount: 6}},
 	}
 
 	for _, tt := range tests {
@@ -76,23 +84,27 @@ func TestGetRoundConfig(t *testing.T) {
 	}
 }
 
-func TestRoundAmount(t *testing.T) {
+func TestRoundDecimal(t *testing.T) {
 	tests := []struct {
 		name     string
 		amount   float64
 		decimals int
-		expected float64
+		down     string
+		up       string
+		normal   string
 	}{
-		{"round to 1 decimal", 1.2345, 1, 1.2},
-		{"round to 2 decimals", 1.2345, 2, 1.23},
-		{"round to 3 decimals", 1.2345, 3, 1.235},
-		{"round to 4 decimals", 1.2345, 4, 1.2345},
+		{"round to 1 decimal", 1.2345, 1, "1.2", "1.3", "1.2"},
+		{"round to 2 decimals", 1.2345, 2, "1.23", "1.24", "1.23"},
+		{"round to 3 decimals", 1.2345, 3, "1.234", "1.235", "1.235"},
+		{"round to 4 decimals", 1.2345, 4, "1.2345", "1.2345", "1.2345"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := roundAmount(tt.amount, tt.decimals)
-			assert.InDelta(t, tt.expected, result, 0.0001)
+			amount := decimalFromFloat(tt.amount)
+			assert.Equal(t, tt.down, roundDown(amount, tt.decimals).FloatString(tt.decimals))
+			assert.Equal(t, tt.up, roundUp(amount, tt.decimals).FloatString(tt.decimals))
+			assert.Equal(t, tt.normal, roundNormal(amount, tt.decimals).FloatString(tt.decimals))
 		})
 	}
 }
@@ -213,3 +225,591 @@ func TestNewHTTPClient(t *testing.T) {
 	assert.True(t, client.retryEnabled)
 	assert.Equal(t, 3, client.maxRetries)
 }
+
+func newMarketInfoServer(t *testing.T, tickSize string, negRisk bool) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch r.URL.Path {
+		case EndpointGetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":` + tickSize + `}`))
+		case EndpointGetNegRisk:
+			if negRisk {
+				w.Write([]byte(`{"neg_risk":true}`))
+			} else {
+				w.Write([]byte(`{"neg_risk":false}`))
+			}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &calls
+}
+
+func TestGetTickSizeAndNegRiskAreCached(t *testing.T) {
+	server, calls := newMarketInfoServer(t, "0.001", true)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	tickSize, err := client.GetTickSize("123")
+	assert.NoError(t, err)
+	assert.Equal(t, TickSize0001, tickSize)
+
+	negRisk, err := client.GetNegRisk("123")
+	assert.NoError(t, err)
+	assert.True(t, negRisk)
+
+	_, err = client.GetTickSize("123")
+	assert.NoError(t, err)
+	_, err = client.GetNegRisk("123")
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestCreateOrderResolvesOptions(t *testing.T) {
+	server, calls := newMarketInfoServer(t, "0.01", false)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	order := &UserOrder{TokenID: "123", Price: 0.52, Size: 10, Side: SideBuy}
+
+	signed, err := client.CreateOrder(order, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, signed.Signature)
+
+	_, err = client.CreateOrder(order, &CreateOrderOptions{TickSize: TickSize001})
+	assert.NoError(t, err)
+
+	// Both lookups are served from the cache the second time
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	// A price off the market tick is still rejected
+	_, err = client.CreateOrder(&UserOrder{TokenID: "123", Price: 0.525, Size: 10, Side: SideBuy}, nil)
+	assert.Error(t, err)
+}
+
+func TestCreateOrderRejectsMismatchedTickSize(t *testing.T) {
+	server, _ := newMarketInfoServer(t, "0.01", false)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	order := &UserOrder{TokenID: "123", Price: 0.5, Size: 10, Side: SideBuy}
+
+	// A coarser tick size than the market's is refused, even for a price on both grids
+	_, err := client.CreateOrder(order, &CreateOrderOptions{TickSize: TickSize01})
+	assert.ErrorContains(t, err, "market tick size is 0.01")
+
+	// An equal or finer one is accepted
+	signed, err := client.CreateOrder(order, &CreateOrderOptions{TickSize: "0.010"})
+	assert.NoError(t, err)
+	assert.NotNil(t, signed)
+
+	signed, err = client.CreateOrder(order, &CreateOrderOptions{TickSize: TickSize0001})
+	assert.NoError(t, err)
+	assert.Equal(t, "5000000", signed.MakerAmount)
+	assert.Equal(t, "10000000", signed.TakerAmount)
+
+	// but prices must still lie on the market tick
+	_, err = client.CreateOrder(&UserOrder{TokenID: "123", Price: 0.555, Size: 10, Side: SideBuy},
+		&CreateOrderOptions{TickSize: TickSize0001})
+	assert.ErrorContains(t, err, "multiple of tick size 0.01")
+}
+
+func TestValidateOrderPriceChecksMarketTick(t *testing.T) {
+	assert.NoError(t, validateOrderPrice(0.5, TickSize01, TickSize001))
+	assert.ErrorContains(t, validateOrderPrice(0.555, TickSize0001, TickSize001), "multiple of tick size 0.01")
+}
+
+func TestCreateMarketOrderPricesFromBook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointGetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case EndpointGetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		case EndpointGetOrderBook:
+			w.Write([]byte(`{"asset_id":"123","bids":[{"price":"0.50","size":"20"}],` +
+				`"asks":[{"price":"0.55","size":"100"},{"price":"0.53","size":"50"},{"price":"0.52","size":"20"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	order, err := client.CreateMarketOrder(&UserMarketOrder{TokenID: "123", Amount: 30, Side: SideBuy}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "30000000", order.MakerAmount)
+	// 30 / 0.53 = 56.603773... shares
+	assert.Equal(t, "56603700", order.TakerAmount)
+	assert.Equal(t, "0", order.Expiration)
+
+	_, err = client.CreateMarketOrder(&UserMarketOrder{TokenID: "123", Amount: 1000, Side: SideBuy}, nil)
+	assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+
+	fak := OrderTypeFAK
+	order, err = client.CreateMarketOrder(&UserMarketOrder{TokenID: "123", Amount: 10, Side: SideSell, OrderType: &fak}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "10000000", order.MakerAmount)
+	assert.Equal(t, "5000000", order.TakerAmount)
+}
+
+func TestPostOrdersBatchesAndAlignsResponses(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, EndpointPostOrders, r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("POLY_SIGNATURE"))
+
+		body, _ := io.ReadAll(r.Body)
+		var args []PostOrderArgs
+		assert.NoError(t, json.Unmarshal(body, &args))
+		batches = append(batches, len(args))
+
+		responses := make([]OrderResponse, len(args))
+		for i, arg := range args {
+			responses[i] = OrderResponse{Success: true, OrderID: "id-" + arg.Order.TokenID}
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	args := make([]PostOrderArgs, 20)
+	for i := range args {
+		args[i] = PostOrderArgs{Order: SignedOrder{TokenID: fmt.Sprintf("%d", i)}, OrderType: OrderTypeGTC}
+	}
+
+	responses, err := client.PostOrders(args)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{MaxOrdersPerBatch, 5}, batches)
+	assert.Len(t, responses, 20)
+	for i, resp := range responses {
+		assert.Equal(t, fmt.Sprintf("id-%d", i), resp.OrderID)
+	}
+}
+
+func TestPostOrdersIsNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	_, err := client.PostOrders([]PostOrderArgs{{OrderType: OrderTypeGTC}})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestOrderRejectionReason(t *testing.T) {
+	// Every documented error message and code of the order endpoints
+	tests := []struct {
+		message  string
+		code     string
+		expected error
+	}{
+		{"order 0x1 is invalid. Price (0.555) breaks minimum tick size rule: 0.01", "INVALID_ORDER_MIN_TICK_SIZE", ErrOrderMinTickSize},
+		{"order 0x1 is invalid. Size (1) lower than the minimum: 5", "INVALID_ORDER_MIN_SIZE", ErrOrderMinSize},
+		{"order 0x1 is invalid. Duplicated.", "INVALID_ORDER_DUPLICATED", ErrOrderDuplicated},
+		{"not enough balance / allowance", "INVALID_ORDER_NOT_ENOUGH_BALANCE", ErrOrderNotEnoughBalance},
+		{"invalid expiration", "INVALID_ORDER_EXPIRATION", ErrOrderInvalidExpiration},
+		{"could not insert order", "INVALID_ORDER_ERROR", ErrOrderInsertFailed},
+		{"invalid post-only order: only GTC and GTD order types are allowed", "INVALID_POST_ONLY_ORDER_TYPE", ErrOrderPostOnlyType},
+		{"invalid post-only order: order crosses book", "INVALID_POST_ONLY_ORDER", ErrOrderPostOnlyCrosses},
+		{"could not run the execution", "EXECUTION_ERROR", ErrOrderExecutionFailed},
+		{"order match delayed due to market conditions", "ORDER_DELAYED", ErrOrderDelayed},
+		{"error delaying the order", "DELAYING_ORDER_ERROR", ErrOrderDelayFailed},
+		{"order couldn't be fully filled. FOK orders are fully filled or killed.", "FOK_ORDER_NOT_FILLED_ERROR", ErrOrderFOKNotFilled},
+		{"the market is not yet ready to process new orders", "MARKET_NOT_READY", ErrMarketNotReady},
+		{"no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found.", "", ErrOrderNoMatch},
+		{"something new", "SOMETHING_NEW", ErrOrderRejected},
+		{"", "", ErrOrderRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.expected, orderRejectionReason(tt.message))
+			if tt.code != "" {
+				assert.Equal(t, tt.expected, orderRejectionReason(tt.code), tt.code)
+			}
+		})
+	}
+}
+
+func TestPostOrdersSendBuilderHeaders(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		if r.URL.Path == EndpointPostOrders {
+			w.Write([]byte(`[{"success":true}]`))
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	args := PostOrderArgs{OrderType: OrderTypeGTC}
+
+	_, err := client.PostOrder(&args)
+	assert.NoError(t, err)
+	assert.Empty(t, headers.Get("POLY_BUILDER_API_KEY"))
+
+	client.SetBuilderCredentials(&BuilderApiKey{Key: "builder", Secret: "c2VjcmV0", Passphrase: "builder-pass"})
+	_, err = client.PostOrder(&args)
+	assert.NoError(t, err)
+	assert.Equal(t, "builder", headers.Get("POLY_BUILDER_API_KEY"))
+	assert.Equal(t, "builder-pass", headers.Get("POLY_BUILDER_PASSPHRASE"))
+	assert.Equal(t, headers.Get("POLY_TIMESTAMP"), headers.Get("POLY_BUILDER_TIMESTAMP"))
+	assert.NotEmpty(t, headers.Get("POLY_BUILDER_SIGNATURE"))
+
+	_, err = client.PostOrders([]PostOrderArgs{args})
+	assert.NoError(t, err)
+	assert.Equal(t, "builder", headers.Get("POLY_BUILDER_API_KEY"))
+}
+
+func TestDeprecatedCredentialFields(t *testing.T) {
+	client := NewClobClient("http://localhost", 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	assert.Equal(t, testCreds(1), client.Creds)
+
+	// Until the accessors are used, later writes to the fields are honoured
+	client.Creds = testCreds(4)
+	assert.Equal(t, "key-4", client.Credentials().Key)
+
+	// Clients created without credentials still accept them through the fields
+	client = NewClobClient("http://localhost", 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	client.Creds = testCreds(2)
+	client.BuilderCreds = &BuilderApiKey{Key: "builder-2"}
+	assert.Equal(t, "key-2", client.Credentials().Key)
+	assert.Equal(t, "builder-2", client.BuilderCredentials().Key)
+
+	// The accessors take precedence
+	client.SetCredentials(testCreds(3))
+	client.SetBuilderCredentials(&BuilderApiKey{Key: "builder-3"})
+	assert.Equal(t, "key-3", client.Credentials().Key)
+	assert.Equal(t, "builder-3", client.BuilderCredentials().Key)
+}
+
+func TestSetCredentialsNilClears(t *testing.T) {
+	client := NewClobClient("http://localhost", 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	client.BuilderCreds = &BuilderApiKey{Key: "builder-1"}
+
+	client.SetCredentials(nil)
+	client.SetBuilderCredentials(nil)
+	assert.Nil(t, client.Credentials())
+	assert.Nil(t, client.BuilderCredentials())
+
+	// The deprecated fields are no longer consulted
+	client.Creds = testCreds(2)
+	client.BuilderCreds = &BuilderApiKey{Key: "builder-2"}
+	assert.Nil(t, client.Credentials())
+	assert.Nil(t, client.BuilderCredentials())
+
+	_, err := client.CancelOrder("order-1")
+	assert.ErrorContains(t, err, "API credentials required")
+}
+
+func TestPostOrderReturnsOrderError(t *testing.T) {
+	status := http.StatusOK
+	var body interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	args := &PostOrderArgs{OrderType: OrderTypeGTC}
+
+	// Rejected in the response
+	body = OrderResponse{Success: false, ErrorMsg: "not enough balance / allowance", OrderID: "0x1"}
+	resp, err := client.PostOrder(args)
+	assert.ErrorIs(t, err, ErrOrderNotEnoughBalance)
+	assert.NotNil(t, resp)
+	var orderErr *OrderError
+	assert.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, "0x1", orderErr.OrderID)
+	assert.Nil(t, orderErr.APIError)
+
+	// Rejected with a 400
+	status = http.StatusBadRequest
+	body = map[string]string{"error": "invalid post-only order: order crosses book"}
+	resp, err = client.PostOrder(args)
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrOrderPostOnlyCrosses)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	// Other API errors are not rejections
+	status = http.StatusUnauthorized
+	body = map[string]string{"error": "Unauthorized/Invalid api key"}
+	_, err = client.PostOrder(args)
+	assert.False(t, errors.As(err, &orderErr))
+	assert.ErrorAs(t, err, &apiErr)
+
+	// Accepted
+	status = http.StatusOK
+	body = OrderResponse{Success: true, OrderID: "0x2", Status: "live"}
+	resp, err = client.PostOrder(args)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Err())
+}
+
+func TestCreateAndPostOrders(t *testing.T) {
+	var posted []PostOrderArgs
+	var tickSizeCalls, negRiskCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointGetTickSize:
+			atomic.AddInt32(&tickSizeCalls, 1)
+			w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case EndpointGetNegRisk:
+			atomic.AddInt32(&negRiskCalls, 1)
+			w.Write([]byte(`{"neg_risk":false}`))
+		case EndpointPostOrders:
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &posted))
+			json.NewEncoder(w).Encode(make([]OrderResponse, len(posted)))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	orders := []BatchOrder{
+		{Order: &UserOrder{TokenID: "1", Price: 0.40, Size: 10, Side: SideBuy}, OrderType: OrderTypeGTC},
+		{Order: &UserOrder{TokenID: "1", Price: 0.60, Size: 10, Side: SideSell}, OrderType: OrderTypeGTC},
+		{Order: &UserOrder{TokenID: "2", Price: 0.30, Size: 5, Side: SideBuy}, OrderType: OrderTypeGTD},
+	}
+
+	responses, err := client.CreateAndPostOrders(orders)
+	assert.NoError(t, err)
+	assert.Len(t, responses, 3)
+	assert.Len(t, posted, 3)
+	for i, arg := range posted {
+		assert.Equal(t, orders[i].Order.TokenID, arg.Order.TokenID)
+		assert.Equal(t, orders[i].Order.Side, arg.Order.Side)
+		assert.Equal(t, orders[i].OrderType, arg.OrderType)
+		assert.NotEmpty(t, arg.Order.Signature)
+	}
+
+	// The market options are fetched once per token, not once per order
+	assert.Equal(t, int32(2), atomic.LoadInt32(&tickSizeCalls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&negRiskCalls))
+
+	// A single invalid order prevents the whole batch from being posted
+	posted = nil
+	orders[1].Order = &UserOrder{TokenID: "1", Price: 0.605, Size: 10, Side: SideSell}
+	_, err = client.CreateAndPostOrders(orders)
+	assert.ErrorContains(t, err, "order 1")
+	assert.Nil(t, posted)
+}
+
+func TestRunBounded(t *testing.T) {
+	var inFlight, peak int32
+	err := runBounded(context.Background(), 20, 3, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+
+	// The failure is reported, not the cancellation it caused
+	boom := errors.New("boom")
+	err = runBounded(context.Background(), 4, 4, func(ctx context.Context, i int) error {
+		if i == 2 {
+			return boom
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestCancelOrdersChunksAndMergesResults(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, EndpointCancelOrders, r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("POLY_SIGNATURE"))
+
+		body, _ := io.ReadAll(r.Body)
+		var ids []string
+		assert.NoError(t, json.Unmarshal(body, &ids))
+		batches = append(batches, len(ids))
+
+		// Every order but the first of each batch is canceled
+		result := CancelResponse{
+			Canceled:    ids[1:],
+			NotCanceled: map[string]string{ids[0]: "order already matched"},
+		}
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	ids := make([]string, MaxCancelOrdersPerBatch+10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("0x%d", i)
+	}
+
+	result, err := client.CancelOrders(ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{MaxCancelOrdersPerBatch, 10}, batches)
+	assert.Len(t, result.Canceled, len(ids)-2)
+	assert.Equal(t, map[string]string{
+		"0x0": "order already matched",
+		fmt.Sprintf("0x%d", MaxCancelOrdersPerBatch): "order already matched",
+	}, result.NotCanceled)
+}
+
+func TestCancelOrdersReturnsPartialResultOnError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(CancelResponse{Canceled: []string{"0xa"}})
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	ids := make([]string, MaxCancelOrdersPerBatch+1)
+	for i := range ids {
+		ids[i] = "0xa"
+	}
+
+	result, err := client.CancelOrders(ids)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, []string{"0xa"}, result.Canceled)
+}
+
+func TestCancelMethodsDecodeCancelResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.Write([]byte(`{"canceled":["0xa"],"not_canceled":{"0xb":"order not found"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	market := "0xmarket"
+
+	calls := map[string]func() (*CancelResponse, error){
+		"CancelOrder": func() (*CancelResponse, error) { return client.CancelOrder("0xa") },
+		"CancelAll":   client.CancelAll,
+		"CancelMarketOrders": func() (*CancelResponse, error) {
+			return client.CancelMarketOrders(&OrderMarketCancelParams{Market: &market})
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			result, err := call()
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"0xa"}, result.Canceled)
+			assert.Equal(t, map[string]string{"0xb": "order not found"}, result.NotCanceled)
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetOrder+"/0xabc", r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("POLY_SIGNATURE"))
+		w.Write([]byte(`{"id":"0xabc","status":"live","side":"BUY","order_type":"GTC",` +
+			`"original_size":"19.3","size_matched":"7.1","price":"0.57"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	order, err := client.GetOrder("0xabc")
+	assert.NoError(t, err)
+	assert.Equal(t, OrderStatusLive, order.Status)
+	assert.True(t, order.Status.IsOpen())
+	assert.Equal(t, SideBuy, order.Side)
+	assert.Equal(t, OrderTypeGTC, order.OrderType)
+
+	price, err := order.PriceValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.57, price)
+
+	remaining, err := order.RemainingSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 12.2, remaining)
+}
+
+func TestOpenOrderRemainingSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		originalSize string
+		sizeMatched  string
+		expected     float64
+		expectErr    bool
+	}{
+		{"unmatched", "10", "0", 10, false},
+		{"no matched size", "10", "", 10, false},
+		{"partially matched", "0.3", "0.1", 0.2, false},
+		{"fully matched", "19.3", "19.3", 0, false},
+		{"invalid size", "abc", "0", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &OpenOrder{OriginalSize: tt.originalSize, SizeMatched: tt.sizeMatched}
+			remaining, err := order.RemainingSize()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, remaining)
+			}
+		})
+	}
+}
+
+func TestOrderStatusIsOpen(t *testing.T) {
+	assert.True(t, OrderStatusLive.IsOpen())
+	assert.True(t, OrderStatusDelayed.IsOpen())
+	assert.True(t, OrderStatusUnmatched.IsOpen())
+	assert.False(t, OrderStatusMatched.IsOpen())
+	assert.False(t, OrderStatusCanceled.IsOpen())
+}
+
+func TestGetLastTradePrice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetLastTradePrice, r.URL.Path)
+		assert.Equal(t, "123", r.URL.Query().Get("token_id"))
+		w.Write([]byte(`{"price":"0.57","side":"SELL"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	lastTrade, err := client.GetLastTradePrice("123")
+	assert.NoError(t, err)
+	assert.Equal(t, &LastTradePrice{TokenID: "123", Price: "0.57", Side: SideSell}, lastTrade)
+
+	price, err := lastTrade.PriceValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.57, price)
+}
@@ -0,0 +1,108 @@
+package clobclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPrivateKey = "0x1234567890123456789012345678901234567890123456789012345678901234"
+
+// Run with -race to verify that a shared client has no data races
+func TestClobClientConcurrentUse(t *testing.T) {
+	var mismatched int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Each request must be signed with a single, consistent set of credentials
+		key := r.Header.Get("POLY_API_KEY")
+		passphrase := r.Header.Get("POLY_PASSPHRASE")
+		if key != "" && strings.TrimPrefix(key, "key-") != strings.TrimPrefix(passphrase, "pass-") {
+			atomic.AddInt32(&mismatched, 1)
+		}
+		w.Write([]byte(`{"next_cursor":"LTE=","data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(0), SignatureTypeEOA, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_, err := client.GetAllOpenOrders(&OpenOrderParams{})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 50; i++ {
+			client.SetCredentials(testCreds(i))
+			client.SetBuilderCredentials(&BuilderApiKey{Key: fmt.Sprintf("builder-%d", i)})
+			client.SetRetryPolicy(DefaultRetryPolicy())
+			client.SetRateLimiter(NewRateLimiter(map[EndpointGroup]RateLimit{
+				EndpointGroupData: {Rate: 10000, Burst: 1000},
+			}))
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			tokenID := fmt.Sprintf("%d", i%10)
+			client.storeTickSize(tokenID, TickSize001)
+			client.storeNegRisk(tokenID, i%2 == 0)
+			client.cachedTickSize(tokenID)
+			client.cachedNegRisk(tokenID)
+			if i%25 == 0 {
+				client.ClearCaches()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mismatched))
+	assert.Equal(t, "key-50", client.Credentials().Key)
+	assert.Equal(t, "builder-50", client.BuilderCredentials().Key)
+}
+
+func TestClobClientCacheExpiry(t *testing.T) {
+	client := NewClobClient("http://localhost", 137, "", nil, SignatureTypeEOA, nil)
+
+	_, ok := client.cachedTickSize("123")
+	assert.False(t, ok)
+
+	client.storeTickSize("123", TickSize0001)
+	tickSize, ok := client.cachedTickSize("123")
+	assert.True(t, ok)
+	assert.Equal(t, TickSize0001, tickSize)
+
+	client.cacheMu.Lock()
+	entry := client.tickSizeCache["123"]
+	entry.timestamp = time.Now().Add(-2 * cacheTTL)
+	client.tickSizeCache["123"] = entry
+	client.cacheMu.Unlock()
+
+	_, ok = client.cachedTickSize("123")
+	assert.False(t, ok)
+}
+
+func testCreds(i int) *ApiKeyCreds {
+	return &ApiKeyCreds{
+		Key:        fmt.Sprintf("key-%d", i),
+		Secret:     "c2VjcmV0",
+		Passphrase: fmt.Sprintf("pass-%d", i),
+	}
+}
@@ -0,0 +1,56 @@
+package clobclient
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ContractConfig holds the contract addresses the CLOB uses on a chain
+type ContractConfig struct {
+	Exchange          string `json:"exchange"`
+	NegRiskExchange   string `json:"negRiskExchange"`
+	NegRiskAdapter    string `json:"negRiskAdapter"`
+	Collateral        string `json:"collateral"`
+	ConditionalTokens string `json:"conditionalTokens"`
+}
+
+var (
+	contractConfigsMu sync.RWMutex
+	contractConfigs   = map[int]ContractConfig{
+		int(ChainPolygon): {
+			Exchange:          "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E",
+			NegRiskExchange:   "0xC5d563A36AE78145C45a50134d48A1215220f80a",
+			NegRiskAdapter:    "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+			Collateral:        "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174",
+			ConditionalTokens: "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045",
+		},
+		int(ChainAmoy): {
+			Exchange:          "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40",
+			NegRiskExchange:   "0xC5d563A36AE78145C45a50134d48A1215220f80a",
+			NegRiskAdapter:    "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+			Collateral:        "0x9c4e1703476e875070ee25b56a58b008cfb8fa78",
+			ConditionalTokens: "0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB",
+		},
+	}
+)
+
+// GetContractConfig returns the contract addresses registered for a chain
+func GetContractConfig(chainID int) (ContractConfig, error) {
+	contractConfigsMu.RLock()
+	defer contractConfigsMu.RUnlock()
+
+	config, ok := contractConfigs[chainID]
+	if !ok {
+		return ContractConfig{}, fmt.Errorf("no contract config for chain %d", chainID)
+	}
+
+	return config, nil
+}
+
+// SetContractConfig registers or overrides the contract addresses for a chain
+func SetContractConfig(chainID int, config ContractConfig) {
+	contractConfigsMu.Lock()
+	defer contractConfigsMu.Unlock()
+
+	contractConfigs[chainID] = config
+}
@@ -0,0 +1,83 @@
+package clobclient
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Exact decimal helpers used for order amounts. Floats are converted to the
+// shortest decimal that round-trips (0.57 becomes exactly 57/100), and all
+// arithmetic and rounding then happens on big.Rat without representation error.
+
+// tokenDecimals is the number of decimals of USDC and conditional tokens
+const tokenDecimals = 6
+
+// maxDecimalPlaces bounds the search in decimalPlaces
+const maxDecimalPlaces = 18
+
+// decimalFromFloat returns the exact decimal value f prints as
+func decimalFromFloat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// decimalFromString parses a decimal string such as "0.57"
+func decimalFromString(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+// pow10 returns 10^n as a big.Int
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundDown rounds r toward negative infinity to the given number of decimals
+func roundDown(r *big.Rat, decimals int) *big.Rat {
+	scale := pow10(decimals)
+	num := new(big.Int).Mul(r.Num(), scale)
+	// big.Int.Div is Euclidean division, i.e. floor for a positive denominator
+	num.Div(num, r.Denom())
+	return new(big.Rat).SetFrac(num, scale)
+}
+
+// roundUp rounds r toward positive infinity to the given number of decimals
+func roundUp(r *big.Rat, decimals int) *big.Rat {
+	down := roundDown(new(big.Rat).Neg(r), decimals)
+	return down.Neg(down)
+}
+
+// roundNormal rounds r to the nearest value with the given number of decimals, halves up
+func roundNormal(r *big.Rat, decimals int) *big.Rat {
+	half := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Mul(big.NewInt(2), pow10(decimals)))
+	return roundDown(new(big.Rat).Add(r, half), decimals)
+}
+
+// decimalPlaces returns the number of decimals needed to write r exactly, or
+// maxDecimalPlaces+1 if r has no finite decimal representation that short
+func decimalPlaces(r *big.Rat) int {
+	scaled := new(big.Rat).Set(r)
+	ten := new(big.Rat).SetInt64(10)
+	for d := 0; d <= maxDecimalPlaces; d++ {
+		if scaled.IsInt() {
+			return d
+		}
+		scaled.Mul(scaled, ten)
+	}
+	return maxDecimalPlaces + 1
+}
+
+// toTokenUnits converts a decimal amount to integer token units (6 decimals),
+// truncating anything beyond the token precision
+func toTokenUnits(r *big.Rat) string {
+	units := roundDown(r, tokenDecimals)
+	units.Mul(units, new(big.Rat).SetInt(pow10(tokenDecimals)))
+	return units.Num().String()
+}
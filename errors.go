@@ -0,0 +1,228 @@
+package clobclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInsufficientLiquidity is returned when the order book is too thin to fill a market order
+var ErrInsufficientLiquidity = errors.New("insufficient liquidity")
+
+// ErrEmptyBook is returned when a book statistic needs a side of the book that has no levels
+var ErrEmptyBook = errors.New("order book side is empty")
+
+// ErrCursorLoop is returned by a PageIterator when the server sends a cursor it
+// already served, which would otherwise make the iteration loop forever
+var ErrCursorLoop = errors.New("pagination cursor repeated")
+
+// Reasons the exchange gives for rejecting an order. Errors returned by
+// PostOrder and OrderResponse.Err match them with errors.Is:
+//
+//	if errors.Is(err, clobclient.ErrOrderNotEnoughBalance) {
+//		// top up or lower the size
+//	}
+var (
+	ErrOrderMinTickSize       = errors.New("price breaks minimum tick size rules")
+	ErrOrderMinSize           = errors.New("size lower than the minimum")
+	ErrOrderDuplicated        = errors.New("order already placed")
+	ErrOrderNotEnoughBalance  = errors.New("not enough balance or allowance")
+	ErrOrderInvalidExpiration = errors.New("invalid expiration")
+	ErrOrderFOKNotFilled      = errors.New("FOK order could not be fully filled")
+	ErrOrderPostOnlyType      = errors.New("post-only orders must be GTC or GTD")
+	ErrOrderPostOnlyCrosses   = errors.New("post-only order crosses the book")
+	ErrOrderNoMatch           = errors.New("no orders found to match")
+	ErrOrderDelayed           = errors.New("order match delayed")
+	ErrOrderDelayFailed       = errors.New("could not delay the order")
+	ErrOrderInsertFailed      = errors.New("could not insert order")
+	ErrOrderExecutionFailed   = errors.New("could not run the execution")
+	ErrMarketNotReady         = errors.New("market not ready for new orders")
+	// ErrOrderRejected is the reason of rejections that match no other reason
+	ErrOrderRejected = errors.New("order rejected")
+)
+
+// orderRejections maps fragments of the exchange's error messages, or of the
+// error codes it sometimes sends instead, to the reason they report. The
+// first match wins, so specific fragments come first.
+var orderRejections = []struct {
+	reason    error
+	fragments []string
+}{
+	{ErrOrderMinTickSize, []string{"minimum tick size", "min_tick_size"}},
+	{ErrOrderMinSize, []string{"lower than the minimum", "min_size"}},
+	{ErrOrderDuplicated, []string{"duplicated"}},
+	{ErrOrderNotEnoughBalance, []string{"not enough balance", "not_enough_balance", "allowance"}},
+	{ErrOrderInvalidExpiration, []string{"expiration"}},
+	{ErrOrderFOKNotFilled, []string{"fok orders are fully filled", "fok_order_not_filled"}},
+	{ErrOrderPostOnlyType, []string{"only gtc and gtd", "invalid_post_only_order_type"}},
+	{ErrOrderPostOnlyCrosses, []string{"post-only", "post only", "post_only", "crosses book", "crosses the book"}},
+	{ErrOrderNoMatch, []string{"no orders found to match"}},
+	{ErrOrderDelayFailed, []string{"error delaying", "delaying_order_error"}},
+	{ErrOrderDelayed, []string{"match delayed", "order_delayed"}},
+	{ErrMarketNotReady, []string{"not yet ready", "market_not_ready"}},
+	{ErrOrderInsertFailed, []string{"could not insert order", "invalid_order_error"}},
+	{ErrOrderExecutionFailed, []string{"could not run the execution", "execution_error"}},
+}
+
+// orderRejectionReason returns the reason an exchange error message reports,
+// or ErrOrderRejected if it is not a known one
+func orderRejectionReason(message string) error {
+	message = strings.ToLower(message)
+	for _, rejection := range orderRejections {
+		for _, fragment := range rejection.fragments {
+			if strings.Contains(message, fragment) {
+				return rejection.reason
+			}
+		}
+	}
+	return ErrOrderRejected
+}
+
+// OrderError is returned when the exchange rejects an order, either in an
+// unsuccessful OrderResponse or with a 400 response. It matches its Reason
+// with errors.Is, and its APIError, if any, with errors.As.
+type OrderError struct {
+	// Reason is one of the ErrOrder* errors, or ErrMarketNotReady
+	Reason error
+	// Message is the exchange's own message
+	Message string
+	// OrderID is the ID of the rejected order, when the exchange reports it
+	OrderID string
+	// APIError is the failed response, for rejections sent as an HTTP error
+	APIError *APIError
+}
+
+// Error implements the error interface
+func (e *OrderError) Error() string {
+	if e.Message == "" {
+		return e.Reason.Error()
+	}
+	return fmt.Sprintf("%v: %s", e.Reason, e.Message)
+}
+
+// Unwrap returns the reason and, if any, the API error
+func (e *OrderError) Unwrap() []error {
+	if e.APIError != nil {
+		return []error{e.Reason, e.APIError}
+	}
+	return []error{e.Reason}
+}
+
+// asOrderError converts an API error that rejects an order into an
+// *OrderError. Other errors are returned unchanged.
+func asOrderError(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message == "" {
+		return err
+	}
+
+	reason := orderRejectionReason(apiErr.Message)
+	if reason == ErrOrderRejected && apiErr.StatusCode != http.StatusBadRequest {
+		return err
+	}
+
+	return &OrderError{Reason: reason, Message: apiErr.Message, APIError: apiErr}
+}
+
+// APIError is returned when the CLOB API responds with a non-2xx status.
+// Every ClobClient method wraps it, so callers can inspect it with errors.As:
+//
+//	var apiErr *clobclient.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+//		// refresh credentials
+//	}
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Body       string
+	// Message is the decoded "error" field of the response body, if any
+	Message   string
+	RateLimit RateLimitHeaders
+}
+
+// RateLimitHeaders holds the rate limit headers returned with a response
+type RateLimitHeaders struct {
+	Limit      string
+	Remaining  string
+	Reset      string
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = e.Body
+	}
+	return fmt.Sprintf("%s %s failed with status %d: %s", e.Method, e.Path, e.StatusCode, detail)
+}
+
+// IsRateLimited reports whether the request was throttled by the server
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusTooEarly
+}
+
+// IsServerError reports whether the server failed to process the request
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500
+}
+
+// newAPIError builds an APIError from a non-2xx response
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RateLimit:  parseRateLimitHeaders(resp.Header),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	var decoded struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		apiErr.Message = decoded.Error
+	}
+
+	return apiErr
+}
+
+// parseRateLimitHeaders extracts the rate limit headers from a response
+func parseRateLimitHeaders(header http.Header) RateLimitHeaders {
+	return RateLimitHeaders{
+		Limit:      header.Get("X-RateLimit-Limit"),
+		Remaining:  header.Get("X-RateLimit-Remaining"),
+		Reset:      header.Get("X-RateLimit-Reset"),
+		RetryAfter: parseRetryAfter(header.Get("Retry-After")),
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
@@ -48,7 +48,7 @@ func main() {
 	fmt.Printf("API Key: %s\n", creds.Key)
 
 	// Update client with credentials
-	client.Creds = creds
+	client.SetCredentials(creds)
 
 	// Example order parameters
 	tokenID := "your-token-id" // Replace with actual token ID
@@ -59,15 +59,10 @@ func main() {
 		Side:    clob.SideBuy,
 	}
 
-	options := &clob.CreateOrderOptions{
-		TickSize: clob.TickSize0001,
-		NegRisk:  boolPtr(false),
-	}
-
 	fmt.Println("\nCreating order...")
 
-	// Create the signed order
-	signedOrder, err := client.CreateOrder(order, options)
+	// Create the signed order; tick size and neg-risk are resolved from the market
+	signedOrder, err := client.CreateOrder(order, nil)
 	if err != nil {
 		log.Fatalf("Failed to create order: %v", err)
 	}
@@ -103,7 +98,3 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
-
-func boolPtr(b bool) *bool {
-	return &b
-}
@@ -39,11 +39,14 @@ func main() {
 		fmt.Printf("Neg Risk: %v\n", book.NegRisk)
 		fmt.Printf("Number of Bids: %d\n", len(book.Bids))
 		fmt.Printf("Number of Asks: %d\n", len(book.Asks))
-		if len(book.Bids) > 0 {
-			fmt.Printf("Best Bid: %s @ %s\n", book.Bids[0].Size, book.Bids[0].Price)
+		if bids, err := book.SortedBids(); err == nil && len(bids) > 0 {
+			fmt.Printf("Best Bid: %v @ %v\n", bids[0].Size, bids[0].Price)
 		}
-		if len(book.Asks) > 0 {
-			fmt.Printf("Best Ask: %s @ %s\n", book.Asks[0].Size, book.Asks[0].Price)
+		if asks, err := book.SortedAsks(); err == nil && len(asks) > 0 {
+			fmt.Printf("Best Ask: %v @ %v\n", asks[0].Size, asks[0].Price)
+		}
+		if spread, err := book.Spread(); err == nil {
+			fmt.Printf("Spread: %v\n", spread)
 		}
 	}
 
@@ -44,12 +44,12 @@ func main() {
 		log.Fatalf("Failed to get API key: %v", err)
 	}
 
-	client.Creds = creds
+	client.SetCredentials(creds)
 	fmt.Println("Authenticated successfully!")
 
 	// Get open orders
 	fmt.Println("\n1. Fetching open orders...")
-	orders, err := client.GetOpenOrders(&clob.OpenOrderParams{})
+	orders, err := client.GetAllOpenOrders(&clob.OpenOrderParams{})
 	if err != nil {
 		log.Printf("Failed to get open orders: %v", err)
 	} else {
@@ -62,7 +62,7 @@ func main() {
 
 	// Get trades
 	fmt.Println("\n2. Fetching trades...")
-	trades, err := client.GetTrades(&clob.TradeParams{})
+	trades, err := client.GetAllTrades(&clob.TradeParams{})
 	if err != nil {
 		log.Printf("Failed to get trades: %v", err)
 	} else {
@@ -96,18 +96,21 @@ func main() {
 		if err != nil {
 			log.Printf("Failed to cancel order: %v", err)
 		} else {
-			fmt.Printf("Order canceled: %v\n", response.Success)
+			fmt.Printf("Canceled: %v, not canceled: %v\n", response.Canceled, response.NotCanceled)
 		}
 	*/
 
 	// Example: Cancel all orders (commented out for safety)
 	/*
 		fmt.Println("\n5. Canceling all orders...")
-		err = client.CancelAll()
+		result, err := client.CancelAll()
 		if err != nil {
 			log.Printf("Failed to cancel all orders: %v", err)
 		} else {
-			fmt.Println("All orders canceled successfully!")
+			fmt.Printf("Canceled %d orders\n", len(result.Canceled))
+			for id, reason := range result.NotCanceled {
+				fmt.Printf("Not canceled %s: %s\n", id, reason)
+			}
 		}
 	*/
 
@@ -2,37 +2,109 @@ package clobclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"sync"
 	"time"
 )
 
-// HTTPClient handles HTTP requests with retry logic
+// HTTPClient handles HTTP requests with retry logic. It is safe for
+// concurrent use; the retry policy and rate limiter may be replaced while
+// requests are in flight.
 type HTTPClient struct {
+	mu           sync.RWMutex
 	client       *http.Client
 	retryEnabled bool
 	maxRetries   int
+	retryPolicy  RetryPolicy
+	rateLimiter  *RateLimiter
 }
 
 // NewHTTPClient creates a new HTTP client
 func NewHTTPClient(timeout time.Duration, retryEnabled bool) *HTTPClient {
+	policy := DefaultRetryPolicy()
 	return &HTTPClient{
 		client: &http.Client{
 			Timeout: timeout,
 		},
 		retryEnabled: retryEnabled,
-		maxRetries:   3,
+		maxRetries:   policy.MaxAttempts,
+		retryPolicy:  policy,
 	}
 }
 
+// SetRetryPolicy replaces the retry policy used by the client
+func (c *HTTPClient) SetRetryPolicy(policy RetryPolicy) {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.retryPolicy = policy
+	c.maxRetries = policy.MaxAttempts
+}
+
+// RetryPolicy returns the retry policy used by the client
+func (c *HTTPClient) RetryPolicy() RetryPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.retryPolicy
+}
+
+// SetRateLimiter paces requests through limiter; nil disables client-side pacing
+func (c *HTTPClient) SetRateLimiter(limiter *RateLimiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rateLimiter = limiter
+}
+
+// RateLimiter returns the rate limiter pacing the client, if any
+func (c *HTTPClient) RateLimiter() *RateLimiter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.rateLimiter
+}
+
 // Request performs an HTTP request with optional retry logic
 func (c *HTTPClient) Request(
 	method string,
 	url string,
 	headers map[string]string,
 	body interface{},
+) ([]byte, error) {
+	return c.RequestWithContext(context.Background(), method, url, headers, body)
+}
+
+// RequestWithContext performs an HTTP request bound to ctx. Cancelling ctx
+// aborts the in-flight request as well as any pending retry sleep.
+func (c *HTTPClient) RequestWithContext(
+	ctx context.Context,
+	method string,
+	url string,
+	headers map[string]string,
+	body interface{},
+) ([]byte, error) {
+	return c.request(ctx, method, url, headers, body, isIdempotentMethod(method))
+}
+
+// request performs an HTTP request, retrying according to the retry policy.
+// idempotent tells whether the request may safely be sent more than once.
+func (c *HTTPClient) request(
+	ctx context.Context,
+	method string,
+	url string,
+	headers map[string]string,
+	body interface{},
+	idempotent bool,
 ) ([]byte, error) {
 	var requestBody []byte
 	var err error
@@ -44,28 +116,47 @@ func (c *HTTPClient) Request(
 		}
 	}
 
-	var lastErr error
+	// Snapshot the settings so that concurrent updates don't affect this request
+	c.mu.RLock()
+	policy := c.retryPolicy
+	limiter := c.rateLimiter
 	retries := 1
 	if c.retryEnabled {
 		retries = c.maxRetries
 	}
+	c.mu.RUnlock()
 
+	var lastErr error
 	for i := 0; i < retries; i++ {
-		resp, err := c.doRequest(method, url, headers, requestBody)
+		if limiter != nil {
+			if err := limiter.Wait(ctx, endpointGroupForURL(method, url)); err != nil {
+				return nil, fmt.Errorf("rate limiter: %w", err)
+			}
+		}
+
+		resp, err := c.doRequest(ctx, method, url, headers, requestBody)
 		if err == nil {
 			return resp, nil
 		}
 
 		lastErr = err
 
+		// Never retry once the caller has given up
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
 		// Only retry on specific errors (5xx, timeout, etc.)
-		if !c.shouldRetry(err) {
+		if !c.shouldRetry(policy, err, idempotent) {
 			return nil, err
 		}
 
 		// Exponential backoff
 		if i < retries-1 {
-			time.Sleep(time.Duration(i+1) * time.Second)
+			delay := policy.Backoff(i, retryAfter(err))
+			if err := sleepWithContext(ctx, delay); err != nil {
+				return nil, fmt.Errorf("request aborted during retry backoff: %w", err)
+			}
 		}
 	}
 
@@ -74,6 +165,7 @@ func (c *HTTPClient) Request(
 
 // doRequest performs a single HTTP request
 func (c *HTTPClient) doRequest(
+	ctx context.Context,
 	method string,
 	url string,
 	headers map[string]string,
@@ -83,9 +175,9 @@ func (c *HTTPClient) doRequest(
 	var err error
 
 	if body != nil {
-		req, err = http.NewRequest(method, url, bytes.NewBuffer(body))
+		req, err = http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
 	} else {
-		req, err = http.NewRequest(method, url, nil)
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
 	}
 
 	if err != nil {
@@ -101,33 +193,53 @@ func (c *HTTPClient) doRequest(
 	// Perform request
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, &transportError{err: err}
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, &transportError{err: fmt.Errorf("failed to read response: %w", err)}
 	}
 
 	// Check status code
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody)
 	}
 
 	return responseBody, nil
 }
 
 // shouldRetry determines if a request should be retried
-func (c *HTTPClient) shouldRetry(err error) bool {
+func (c *HTTPClient) shouldRetry(policy RetryPolicy, err error, idempotent bool) bool {
 	if !c.retryEnabled {
 		return false
 	}
 
-	// Retry on timeout or 5xx errors
-	// This is a simplified check; in production, you'd want more sophisticated logic
-	return true
+	return policy.isRetryable(err, idempotent)
+}
+
+// endpointGroupForURL returns the rate limit group of a request URL
+func endpointGroupForURL(method string, url string) EndpointGroup {
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return EndpointGroupData
+	}
+	return classifyEndpoint(method, parsed.Path)
+}
+
+// sleepWithContext sleeps for d or until ctx is done, whichever comes first
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // Get performs a GET request
@@ -149,3 +261,23 @@ func (c *HTTPClient) Delete(url string, headers map[string]string, body interfac
 func (c *HTTPClient) Put(url string, headers map[string]string, body interface{}) ([]byte, error) {
 	return c.Request(http.MethodPut, url, headers, body)
 }
+
+// GetWithContext performs a GET request bound to ctx
+func (c *HTTPClient) GetWithContext(ctx context.Context, url string, headers map[string]string) ([]byte, error) {
+	return c.RequestWithContext(ctx, http.MethodGet, url, headers, nil)
+}
+
+// PostWithContext performs a POST request bound to ctx
+func (c *HTTPClient) PostWithContext(ctx context.Context, url string, headers map[string]string, body interface{}) ([]byte, error) {
+	return c.RequestWithContext(ctx, http.MethodPost, url, headers, body)
+}
+
+// DeleteWithContext performs a DELETE request bound to ctx
+func (c *HTTPClient) DeleteWithContext(ctx context.Context, url string, headers map[string]string, body interface{}) ([]byte, error) {
+	return c.RequestWithContext(ctx, http.MethodDelete, url, headers, body)
+}
+
+// PutWithContext performs a PUT request bound to ctx
+func (c *HTTPClient) PutWithContext(ctx context.Context, url string, headers map[string]string, body interface{}) ([]byte, error) {
+	return c.RequestWithContext(ctx, http.MethodPut, url, headers, body)
+}
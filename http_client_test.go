@@ -0,0 +1,251 @@
+package clobclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestWithContextCancelsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewHTTPClient(30*time.Second, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetWithContext(ctx, server.URL, nil)
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRequestWithContextAbortsRetrySleep(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(30*time.Second, true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetWithContext(ctx, server.URL, nil)
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 900*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClobClientWithContextCanceled(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, "", nil, SignatureTypeEOA, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetMidpointWithContext(ctx, "123")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	mid, err := client.GetMidpoint("123")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, mid)
+}
+
+func TestRequestReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"too many requests"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, "", nil, SignatureTypeEOA, nil)
+	client.HTTPClient = NewHTTPClient(30*time.Second, false)
+
+	_, err := client.GetOrderBook("123")
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, EndpointGetOrderBook, apiErr.Path)
+	assert.Equal(t, "too many requests", apiErr.Message)
+	assert.Equal(t, `{"error":"too many requests"}`, apiErr.Body)
+	assert.Equal(t, "0", apiErr.RateLimit.Remaining)
+	assert.Equal(t, 2*time.Second, apiErr.RateLimit.RetryAfter)
+	assert.True(t, apiErr.IsRateLimited())
+	assert.False(t, apiErr.IsServerError())
+}
+
+func TestAPIErrorFallsBackToBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("bad gateway"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(30*time.Second, false)
+
+	_, err := client.Post(server.URL+"/order", nil, nil)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "", apiErr.Message)
+	assert.True(t, apiErr.IsServerError())
+	assert.Equal(t, "POST /order failed with status 502: bad gateway", apiErr.Error())
+}
+
+func fastRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestRequestRetriesRetryableStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(30*time.Second, true)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	_, err := client.Get(server.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRequestDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid signature"}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(30*time.Second, true)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	_, err := client.Get(server.URL, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRequestDoesNotRetryNonIdempotentPost(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(30*time.Second, true)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	_, err := client.Post(server.URL+EndpointPostOrder, nil, map[string]string{"a": "b"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	policy := fastRetryPolicy()
+	policy.RetryNonIdempotent = true
+	client.SetRetryPolicy(policy)
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = client.Post(server.URL+EndpointPostOrder, nil, map[string]string{"a": "b"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRequestRetriesTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := NewHTTPClient(30*time.Second, true)
+	client.SetRetryPolicy(fastRetryPolicy())
+
+	_, err := client.Get(url, nil)
+	assert.Error(t, err)
+
+	var transportErr *transportError
+	assert.True(t, errors.As(err, &transportErr))
+	assert.Contains(t, err.Error(), "after 3 retries")
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(0, 0))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(1, 0))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(2, 0))
+	assert.Equal(t, time.Second, policy.Backoff(10, 0))
+	assert.Equal(t, 3*time.Second, policy.Backoff(0, 3*time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.Backoff(1, 0)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.LessOrEqual(t, delay, 200*time.Millisecond)
+	}
+}
+
+func TestRetryPolicyBackoffWithoutCap(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second}
+
+	assert.Equal(t, time.Second, policy.Backoff(0, 0))
+	assert.Equal(t, 2*time.Second, policy.Backoff(1, 0))
+	assert.Equal(t, 4*time.Second, policy.Backoff(2, 0))
+
+	// Doubling stops short of overflowing
+	assert.Greater(t, policy.Backoff(1000, 0), time.Duration(0))
+
+	reconnect := ReconnectPolicy{BaseDelay: time.Second}
+	assert.Equal(t, 8*time.Second, reconnect.Backoff(3))
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	assert.True(t, isIdempotentMethod(http.MethodGet))
+	assert.True(t, isIdempotentMethod(http.MethodDelete))
+	assert.False(t, isIdempotentMethod(http.MethodPost))
+}
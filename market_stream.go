@@ -0,0 +1,790 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Market channel event types
+const (
+	EventTypeBook           = "book"
+	EventTypePriceChange    = "price_change"
+	EventTypeTickSizeChange = "tick_size_change"
+	EventTypeLastTradePrice = "last_trade_price"
+)
+
+// MarketEvent is an event of the market channel: *BookEvent,
+// *PriceChangeEvent, *TickSizeChangeEvent or *LastTradePriceEvent, or
+// *DisconnectedEvent and *ResyncedEvent around a reconnection
+type MarketEvent interface {
+	marketEvent()
+}
+
+// BookEvent is a full order book snapshot of a token
+type BookEvent struct {
+	EventType string         `json:"event_type"`
+	AssetID   string         `json:"asset_id"`
+	Market    string         `json:"market"`
+	Timestamp string         `json:"timestamp"`
+	Hash      string         `json:"hash"`
+	Bids      []OrderSummary `json:"bids"`
+	Asks      []OrderSummary `json:"asks"`
+}
+
+// UnmarshalJSON also accepts the older buys/sells field names
+func (e *BookEvent) UnmarshalJSON(data []byte) error {
+	type bookEvent BookEvent
+	var decoded struct {
+		bookEvent
+		Buys  []OrderSummary `json:"buys"`
+		Sells []OrderSummary `json:"sells"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*e = BookEvent(decoded.bookEvent)
+	if e.Bids == nil {
+		e.Bids = decoded.Buys
+	}
+	if e.Asks == nil {
+		e.Asks = decoded.Sells
+	}
+	return nil
+}
+
+// Book returns the snapshot as an OrderBookSummary
+func (e *BookEvent) Book() *OrderBookSummary {
+	return &OrderBookSummary{
+		Market:    e.Market,
+		AssetID:   e.AssetID,
+		Timestamp: e.Timestamp,
+		Bids:      append([]OrderSummary(nil), e.Bids...),
+		Asks:      append([]OrderSummary(nil), e.Asks...),
+		Hash:      e.Hash,
+	}
+}
+
+// PriceChangeEvent reports levels of one or more books that changed
+type PriceChangeEvent struct {
+	EventType    string        `json:"event_type"`
+	Market       string        `json:"market"`
+	Timestamp    string        `json:"timestamp"`
+	PriceChanges []PriceChange `json:"price_changes"`
+}
+
+// PriceChange is the new size of a book level. A zero size removes the level.
+type PriceChange struct {
+	AssetID string `json:"asset_id"`
+	Price   string `json:"price"`
+	Size    string `json:"size"`
+	Side    Side   `json:"side"`
+	// Hash is the hash of the book after the change
+	Hash    string `json:"hash"`
+	BestBid string `json:"best_bid"`
+	BestAsk string `json:"best_ask"`
+}
+
+// UnmarshalJSON also accepts the older format, with a single asset and a
+// changes array sharing one hash
+func (e *PriceChangeEvent) UnmarshalJSON(data []byte) error {
+	type priceChangeEvent PriceChangeEvent
+	var decoded struct {
+		priceChangeEvent
+		AssetID string        `json:"asset_id"`
+		Hash    string        `json:"hash"`
+		Changes []PriceChange `json:"changes"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*e = PriceChangeEvent(decoded.priceChangeEvent)
+	for i, change := range decoded.Changes {
+		change.AssetID = decoded.AssetID
+		// The hash describes the book after all changes were applied
+		if i == len(decoded.Changes)-1 {
+			change.Hash = decoded.Hash
+		}
+		e.PriceChanges = append(e.PriceChanges, change)
+	}
+	return nil
+}
+
+// TickSizeChangeEvent reports a new minimum tick size for a token
+type TickSizeChangeEvent struct {
+	EventType   string   `json:"event_type"`
+	AssetID     string   `json:"asset_id"`
+	Market      string   `json:"market"`
+	OldTickSize TickSize `json:"old_tick_size"`
+	NewTickSize TickSize `json:"new_tick_size"`
+	Timestamp   string   `json:"timestamp"`
+}
+
+// LastTradePriceEvent reports a trade of a token
+type LastTradePriceEvent struct {
+	EventType  string `json:"event_type"`
+	AssetID    string `json:"asset_id"`
+	Market     string `json:"market"`
+	Price      string `json:"price"`
+	Side       Side   `json:"side"`
+	Size       string `json:"size"`
+	FeeRateBps string `json:"fee_rate_bps"`
+	Timestamp  string `json:"timestamp"`
+}
+
+func (*BookEvent) marketEvent()           {}
+func (*PriceChangeEvent) marketEvent()    {}
+func (*TickSizeChangeEvent) marketEvent() {}
+func (*LastTradePriceEvent) marketEvent() {}
+
+// decodeMarketEvent decodes a market channel event. Unknown event types
+// return nil without error.
+func decodeMarketEvent(raw json.RawMessage) (MarketEvent, error) {
+	eventType, err := decodeEventType(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var event MarketEvent
+	switch eventType {
+	case EventTypeBook:
+		event = &BookEvent{}
+	case EventTypePriceChange:
+		event = &PriceChangeEvent{}
+	case EventTypeTickSizeChange:
+		event = &TickSizeChangeEvent{}
+	case EventTypeLastTradePrice:
+		event = &LastTradePriceEvent{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(raw, event); err != nil {
+		return nil, fmt.Errorf("failed to parse %s event: %w", eventType, err)
+	}
+	return event, nil
+}
+
+// MarketStream subscribes to the market channel and maintains a local order
+// book per subscribed token. Deltas are applied as they arrive; when a delta
+// does not apply cleanly (no book yet for the asset, an invalid level or a
+// delta older than the book), the book is fetched again through the REST
+// client in the background, at most once per second per asset, and the deltas
+// received meanwhile are replayed on it.
+//
+// Gaps are not detected: a delta lost in transit leaves the book out of sync
+// until the next snapshot. The server sends no sequence numbers, and the hash
+// sent with each delta, the only gap signal, is kept on the book but cannot be
+// checked until the server's book hash is reproduced.
+//
+// The stream keeps itself alive: it sends heartbeats and, when the connection
+// drops, delivers a *DisconnectedEvent, reconnects with backoff, resubscribes,
+// fetches fresh snapshots of every book in the background and delivers a
+// *ResyncedEvent once they are installed. Events received on the new
+// connection meanwhile are delivered as they arrive, so they may precede the
+// snapshots and the *ResyncedEvent.
+//
+//	stream := clobclient.NewMarketStream("", client)
+//	stream.Subscribe(tokenID)
+//	if err := stream.Connect(); err != nil {
+//		// handle error
+//	}
+//	for event := range stream.Events() {
+//		book, _ := stream.Book(tokenID)
+//	}
+type MarketStream struct {
+	client  *ClobClient
+	session *wsSession
+
+	mu     sync.Mutex
+	assets map[string]bool
+	books  map[string]*OrderBookSummary
+	// pending marks the assets whose snapshot is scheduled or in flight;
+	// deltas received meanwhile are buffered and replayed on the snapshot
+	pending      map[string]bool
+	buffered     map[string][]bufferedChange
+	lastSnapshot map[string]time.Time
+	fetches      sync.WaitGroup
+	// deliverMu keeps the delivery of events in the order they were applied
+	// to the books, as snapshots are installed off the read loop
+	deliverMu sync.Mutex
+
+	events     chan MarketEvent
+	finishOnce sync.Once
+}
+
+// resnapshotInterval is the minimum time between two snapshots of the same
+// asset fetched because its book fell out of sync
+const resnapshotInterval = time.Second
+
+// bufferedChange is a delta received while the book's snapshot is fetched
+type bufferedChange struct {
+	timestamp string
+	change    PriceChange
+}
+
+// NewMarketStream creates a market channel stream. url defaults to
+// WSMarketURL; client is used to fetch book snapshots and may be nil, in which
+// case books that fall out of sync are dropped until the next book event.
+func NewMarketStream(url string, client *ClobClient) *MarketStream {
+	if url == "" {
+		url = WSMarketURL
+	}
+
+	s := &MarketStream{
+		client: client,
+		assets: make(map[string]bool),
+		books:  make(map[string]*OrderBookSummary),
+		events: make(chan MarketEvent, 256),
+
+		pending:      make(map[string]bool),
+		buffered:     make(map[string][]bufferedChange),
+		lastSnapshot: make(map[string]time.Time),
+	}
+	s.session = newWSSession("market stream", url, s)
+	return s
+}
+
+// SetReconnectPolicy configures how the stream reconnects. It must be called
+// before Connect.
+func (s *MarketStream) SetReconnectPolicy(policy ReconnectPolicy) {
+	s.session.reconnect = policy
+}
+
+// SetPingInterval configures how often heartbeats are sent; zero disables
+// them. It must be called before Connect.
+func (s *MarketStream) SetPingInterval(interval time.Duration) {
+	s.session.pingInterval = interval
+}
+
+// Connect opens the connection and subscribes to the assets added with Subscribe
+func (s *MarketStream) Connect() error {
+	return s.ConnectWithContext(context.Background())
+}
+
+// ConnectWithContext is like Connect but bound to ctx. ctx only bounds the
+// first handshake; use Close to end the stream.
+func (s *MarketStream) ConnectWithContext(ctx context.Context) error {
+	return s.session.connect(ctx)
+}
+
+// subscription returns the message subscribing a connection to every asset
+func (s *MarketStream) subscription() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.assets))
+	for id := range s.assets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return map[string]interface{}{
+		"assets_ids": ids,
+		"type":       "market",
+	}, nil
+}
+
+// Subscribe adds assets to the stream, sending the subscription right away if
+// the stream is connected
+func (s *MarketStream) Subscribe(assetIDs ...string) error {
+	return s.session.update(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, id := range assetIDs {
+			s.assets[id] = true
+		}
+	}, map[string]interface{}{
+		"assets_ids": assetIDs,
+		"operation":  "subscribe",
+	})
+}
+
+// Unsubscribe removes assets from the stream and drops their books
+func (s *MarketStream) Unsubscribe(assetIDs ...string) error {
+	return s.session.update(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, id := range assetIDs {
+			delete(s.assets, id)
+			delete(s.books, id)
+			delete(s.buffered, id)
+			delete(s.lastSnapshot, id)
+		}
+	}, map[string]interface{}{
+		"assets_ids": assetIDs,
+		"operation":  "unsubscribe",
+	})
+}
+
+// Events returns the channel events are delivered on, in the order they were
+// applied to the local books. Snapshots fetched in the background are
+// delivered between the events read from the connection: before any delta
+// applied on top of them, and after the deltas received while they were
+// fetched, which they already include. The channel is closed when the stream
+// ends; Err tells why.
+func (s *MarketStream) Events() <-chan MarketEvent {
+	return s.events
+}
+
+// Err returns the error that ended the stream, or nil if it was closed
+func (s *MarketStream) Err() error {
+	return s.session.Err()
+}
+
+// Book returns a copy of the local order book of a token
+func (s *MarketStream) Book(assetID string) (*OrderBookSummary, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, ok := s.books[assetID]
+	if !ok {
+		return nil, false
+	}
+	return copyBook(book), true
+}
+
+// Close ends the stream
+func (s *MarketStream) Close() error {
+	return s.session.close()
+}
+
+// finish closes the events channel once the stream has ended and snapshot
+// fetches have delivered. It may be reached from both Close and the
+// connection, so it only acts once.
+func (s *MarketStream) finish() {
+	s.finishOnce.Do(func() {
+		s.fetches.Wait()
+		close(s.events)
+	})
+}
+
+// disconnected drops every book, since deltas are missed until the stream
+// reconnects, and tells the consumer
+func (s *MarketStream) disconnected(err error) bool {
+	s.mu.Lock()
+	s.books = make(map[string]*OrderBookSummary)
+	s.buffered = make(map[string][]bufferedChange)
+	s.mu.Unlock()
+
+	return s.deliver(&DisconnectedEvent{Err: err})
+}
+
+// resynced fetches a fresh snapshot of every subscribed book in the
+// background, with batched requests, so that reads and heartbeats resume on
+// the new connection right away
+func (s *MarketStream) resynced(since time.Time) bool {
+	if s.client == nil {
+		return s.deliver(&ResyncedEvent{Downtime: time.Since(since)})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.assets))
+	for id := range s.assets {
+		ids = append(ids, id)
+		// Deltas that find no book are buffered rather than fetched one by one
+		s.pending[id] = true
+	}
+	sort.Strings(ids)
+
+	s.fetches.Add(1)
+	go s.fetchSnapshots(ids, since)
+	return true
+}
+
+// fetchSnapshots fetches the books of assets in batches, installs and
+// delivers them, then delivers a *ResyncedEvent
+func (s *MarketStream) fetchSnapshots(assetIDs []string, since time.Time) {
+	defer s.fetches.Done()
+
+	params := make([]BookParams, len(assetIDs))
+	for i, id := range assetIDs {
+		params[i] = BookParams{TokenID: id}
+	}
+	books, backfillErr := s.client.GetOrderBooksWithContext(s.session.ctx, params)
+
+	for _, id := range assetIDs {
+		book, ok := books[id]
+		if !ok {
+			if backfillErr == nil {
+				backfillErr = fmt.Errorf("no order book returned for token %s", id)
+			}
+			continue
+		}
+		if !s.installAndDeliver(id, book) {
+			return
+		}
+	}
+
+	s.mu.Lock()
+	now := time.Now()
+	for _, id := range assetIDs {
+		delete(s.pending, id)
+		delete(s.buffered, id)
+		s.lastSnapshot[id] = now
+	}
+	s.mu.Unlock()
+
+	s.deliver(&ResyncedEvent{Downtime: time.Since(since), Err: backfillErr})
+}
+
+// handleMessage applies the events of a message to the local books and
+// delivers them. It returns false once the stream is closed.
+func (s *MarketStream) handleMessage(data []byte) bool {
+	raws, err := splitMessages(data)
+	if err != nil {
+		return true
+	}
+
+	for _, raw := range raws {
+		event, err := decodeMarketEvent(raw)
+		if err != nil || event == nil {
+			continue
+		}
+
+		s.deliverMu.Lock()
+		stale := s.apply(event)
+		delivered := s.deliver(event)
+		s.deliverMu.Unlock()
+		if !delivered {
+			return false
+		}
+
+		for _, assetID := range stale {
+			s.scheduleResnapshot(assetID)
+		}
+	}
+	return true
+}
+
+// scheduleResnapshot fetches a fresh book for an asset in the background, so
+// that a slow request doesn't stall reads and heartbeats. Requests for an
+// asset already being fetched are coalesced, and each asset is fetched at
+// most once per resnapshotInterval.
+func (s *MarketStream) scheduleResnapshot(assetID string) {
+	if s.client == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending[assetID] {
+		return
+	}
+	s.pending[assetID] = true
+	delay := time.Until(s.lastSnapshot[assetID].Add(resnapshotInterval))
+
+	s.fetches.Add(1)
+	go s.fetchSnapshot(assetID, delay)
+}
+
+// fetchSnapshot waits for delay, fetches the book of an asset and delivers it.
+// Without a snapshot, the book stays missing until the next book event or delta.
+func (s *MarketStream) fetchSnapshot(assetID string, delay time.Duration) {
+	defer s.fetches.Done()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-s.session.done:
+			return
+		}
+	}
+
+	book, err := s.client.GetOrderBookWithContext(s.session.ctx, assetID)
+	if err == nil && !s.installAndDeliver(assetID, book) {
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.pending, assetID)
+	delete(s.buffered, assetID)
+	s.lastSnapshot[assetID] = time.Now()
+	s.mu.Unlock()
+}
+
+// installAndDeliver installs a snapshot and delivers it, unless the book no
+// longer needs one. Deltas are applied and delivered under the same lock, so
+// that none applied on top of the snapshot is delivered before it. It returns
+// false once the stream is closed.
+func (s *MarketStream) installAndDeliver(assetID string, book *OrderBookSummary) bool {
+	s.deliverMu.Lock()
+	defer s.deliverMu.Unlock()
+
+	snapshot := s.installSnapshot(assetID, book)
+	if snapshot == nil {
+		return true
+	}
+	return s.deliver(snapshot)
+}
+
+// deliver sends an event to the consumer, giving up if the stream is closed
+func (s *MarketStream) deliver(event MarketEvent) bool {
+	select {
+	case s.events <- event:
+		return true
+	case <-s.session.done:
+		return false
+	}
+}
+
+// apply updates the local books with event and returns the assets whose
+// book is out of sync
+func (s *MarketStream) apply(event MarketEvent) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch e := event.(type) {
+	case *BookEvent:
+		if s.assets[e.AssetID] {
+			book := e.Book()
+			s.carryMarketFields(book, s.books[e.AssetID])
+			s.books[e.AssetID] = book
+		}
+
+	case *PriceChangeEvent:
+		var stale []string
+		// markStale buffers every change of the event for an asset whose book
+		// is fetched again, including those already applied or still to come
+		markStale := func(assetID string) {
+			stale = append(stale, assetID)
+			if s.client == nil {
+				return
+			}
+			for _, change := range e.PriceChanges {
+				if change.AssetID == assetID {
+					s.buffered[assetID] = append(s.buffered[assetID],
+						bufferedChange{timestamp: e.Timestamp, change: change})
+				}
+			}
+		}
+
+		for _, change := range e.PriceChanges {
+			if !s.assets[change.AssetID] || containsString(stale, change.AssetID) {
+				continue
+			}
+
+			book, ok := s.books[change.AssetID]
+			if !ok {
+				markStale(change.AssetID)
+				continue
+			}
+
+			// A delta older than the book means updates arrived out of order
+			if olderThan(e.Timestamp, book.Timestamp) {
+				markStale(change.AssetID)
+				continue
+			}
+
+			book.Timestamp = e.Timestamp
+			if err := applyPriceChange(book, change); err != nil {
+				markStale(change.AssetID)
+				continue
+			}
+			if change.Hash != "" {
+				book.Hash = change.Hash
+			}
+		}
+		for _, assetID := range stale {
+			delete(s.books, assetID)
+		}
+		return stale
+
+	case *TickSizeChangeEvent:
+		if book, ok := s.books[e.AssetID]; ok {
+			book.TickSize = e.NewTickSize
+		}
+		if s.client != nil {
+			s.client.storeTickSize(e.AssetID, e.NewTickSize)
+		}
+
+	case *LastTradePriceEvent:
+		if book, ok := s.books[e.AssetID]; ok {
+			book.LastTradePrice = e.Price
+		}
+	}
+
+	return nil
+}
+
+// carryMarketFields fills in the fields of the book summary that book events
+// don't carry (tick size, minimum order size, neg-risk flag and last trade
+// price) from the previous book of the asset, or else from the client caches,
+// so that stream books carry the same fields as REST snapshots
+func (s *MarketStream) carryMarketFields(book *OrderBookSummary, old *OrderBookSummary) {
+	if old != nil {
+		book.TickSize = old.TickSize
+		book.MinOrderSize = old.MinOrderSize
+		book.NegRisk = old.NegRisk
+		book.LastTradePrice = old.LastTradePrice
+		return
+	}
+	if s.client == nil {
+		return
+	}
+
+	if tickSize, ok := s.client.cachedTickSize(book.AssetID); ok {
+		book.TickSize = tickSize
+	}
+	if negRisk, ok := s.client.cachedNegRisk(book.AssetID); ok {
+		book.NegRisk = negRisk
+	}
+}
+
+// installSnapshot installs a book fetched over REST with the buffered deltas
+// newer than it, and returns it as a book event. It returns nil if the book
+// no longer needs a snapshot.
+func (s *MarketStream) installSnapshot(assetID string, book *OrderBookSummary) *BookEvent {
+	event := &BookEvent{
+		EventType: EventTypeBook,
+		AssetID:   book.AssetID,
+		Market:    book.Market,
+		Timestamp: book.Timestamp,
+		Hash:      book.Hash,
+		Bids:      book.Bids,
+		Asks:      book.Asks,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The asset may have been unsubscribed, or resynced by a book event, meanwhile
+	if !s.assets[assetID] {
+		return nil
+	}
+	if _, ok := s.books[assetID]; ok {
+		return nil
+	}
+
+	// Deltas are compared with the snapshot, not with each other: several
+	// changes of one event share its timestamp
+	installed := copyBook(book)
+	for _, buffered := range s.buffered[assetID] {
+		if !newerThan(buffered.timestamp, book.Timestamp) {
+			continue
+		}
+		if err := applyPriceChange(installed, buffered.change); err != nil {
+			continue
+		}
+		if newerThan(buffered.timestamp, installed.Timestamp) {
+			installed.Timestamp = buffered.timestamp
+		}
+		if buffered.change.Hash != "" {
+			installed.Hash = buffered.change.Hash
+		}
+	}
+	delete(s.buffered, assetID)
+	s.books[assetID] = installed
+
+	return event
+}
+
+// newerThan reports whether timestamp a is after b. Timestamps that don't
+// parse are considered newer, so their deltas are applied.
+func newerThan(a string, b string) bool {
+	ta, errA := strconv.ParseInt(a, 10, 64)
+	tb, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil {
+		return true
+	}
+	return ta > tb
+}
+
+// olderThan reports whether timestamp a is before b. Timestamps that don't
+// parse are not considered older.
+func olderThan(a string, b string) bool {
+	ta, errA := strconv.ParseInt(a, 10, 64)
+	tb, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return ta < tb
+}
+
+// applyPriceChange sets the size of a level of book, keeping the levels in
+// the order the server sends them: ascending prices for bids, descending for
+// asks
+func applyPriceChange(book *OrderBookSummary, change PriceChange) error {
+	var err error
+	switch change.Side {
+	case SideBuy:
+		book.Bids, err = setLevel(book.Bids, change.Price, change.Size, true)
+	case SideSell:
+		book.Asks, err = setLevel(book.Asks, change.Price, change.Size, false)
+	default:
+		err = fmt.Errorf("invalid side %q", change.Side)
+	}
+	return err
+}
+
+// setLevel sets the size of the level at price, inserting or removing it as needed
+func setLevel(levels []OrderSummary, price string, size string, ascending bool) ([]OrderSummary, error) {
+	p, err := decimalFromString(price)
+	if err != nil {
+		return nil, err
+	}
+	sz, err := decimalFromString(size)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, level := range levels {
+		lp, err := decimalFromString(level.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		cmp := lp.Cmp(p)
+		if cmp == 0 {
+			if sz.Sign() == 0 {
+				return append(levels[:i:i], levels[i+1:]...), nil
+			}
+			levels[i].Size = size
+			return levels, nil
+		}
+
+		if (ascending && cmp > 0) || (!ascending && cmp < 0) {
+			if sz.Sign() == 0 {
+				return levels, nil
+			}
+			levels = append(levels[:i:i], append([]OrderSummary{{Price: price, Size: size}}, levels[i:]...)...)
+			return levels, nil
+		}
+	}
+
+	if sz.Sign() == 0 {
+		return levels, nil
+	}
+	return append(levels, OrderSummary{Price: price, Size: size}), nil
+}
+
+// copyBook returns a deep copy of book
+func copyBook(book *OrderBookSummary) *OrderBookSummary {
+	copied := *book
+	copied.Bids = append([]OrderSummary(nil), book.Bids...)
+	copied.Asks = append([]OrderSummary(nil), book.Asks...)
+	return &copied
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,684 @@
+package clobclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWSServer starts a local stand-in for the CLOB servers. WebSocket
+// connections to /ws are passed to handle; other requests go to rest.
+func newWSServer(t *testing.T, handle func(conn *websocket.Conn), rest http.HandlerFunc) (*httptest.Server, string) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ws" {
+			rest(w, r)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}))
+
+	return server, "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+// nextMarketEvent waits for the next event of the stream
+func nextMarketEvent(t *testing.T, stream *MarketStream) MarketEvent {
+	select {
+	case event, ok := <-stream.Events():
+		assert.True(t, ok, "stream ended: %v", stream.Err())
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a market event")
+		return nil
+	}
+}
+
+func TestMarketStreamMaintainsBooks(t *testing.T) {
+	bids := []OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.49", Size: "50"}}
+	asks := []OrderSummary{{Price: "0.53", Size: "50"}, {Price: "0.52", Size: "20"}}
+
+	// The book after a 10 share bid at 0.50 is added
+	updatedBids := append(append([]OrderSummary{}, bids...), OrderSummary{Price: "0.50", Size: "10"})
+
+	snapshot := OrderBookSummary{
+		Market:    "0xmarket",
+		AssetID:   "2",
+		Timestamp: "1700000000300",
+		Bids:      []OrderSummary{{Price: "0.47", Size: "5"}},
+		Asks:      []OrderSummary{{Price: "0.51", Size: "5"}},
+		Hash:      "snapshot",
+	}
+
+	// The stream buffers events, so the server waits for the checks of the
+	// first book before sending the last deltas
+	checked := make(chan struct{})
+
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+		assert.Equal(t, "market", subscription["type"])
+		assert.Equal(t, []interface{}{"1", "2"}, subscription["assets_ids"])
+
+		conn.WriteJSON([]interface{}{
+			BookEvent{EventType: EventTypeBook, AssetID: "1", Market: "0xmarket", Timestamp: "1700000000000", Bids: bids, Asks: asks},
+			BookEvent{EventType: EventTypeBook, AssetID: "999", Market: "0xother"},
+		})
+		conn.WriteJSON(PriceChangeEvent{
+			EventType: EventTypePriceChange,
+			Market:    "0xmarket",
+			Timestamp: "1700000000100",
+			PriceChanges: []PriceChange{
+				{AssetID: "1", Price: "0.50", Size: "10", Side: SideBuy, Hash: "server-hash"},
+			},
+		})
+		conn.WriteJSON(TickSizeChangeEvent{EventType: EventTypeTickSizeChange, AssetID: "1", OldTickSize: TickSize001, NewTickSize: TickSize0001})
+		conn.WriteJSON(LastTradePriceEvent{EventType: EventTypeLastTradePrice, AssetID: "1", Price: "0.52", Side: SideBuy, Size: "5"})
+
+		// A hash that doesn't match the local book is not a reason to resync,
+		// but a delta for a book the stream doesn't have is
+		<-checked
+		conn.WriteJSON(PriceChangeEvent{
+			EventType: EventTypePriceChange,
+			Market:    "0xmarket",
+			Timestamp: "1700000000200",
+			PriceChanges: []PriceChange{
+				{AssetID: "1", Price: "0.49", Size: "0", Side: SideBuy, Hash: "unexpected"},
+				{AssetID: "2", Price: "0.47", Size: "6", Side: SideBuy},
+			},
+		})
+
+		// Keep the connection open until the client closes it
+		conn.ReadMessage()
+	}
+
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetOrderBook, r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("token_id"))
+		json.NewEncoder(w).Encode(snapshot)
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	stream := NewMarketStream(wsURL, client)
+	assert.NoError(t, stream.Subscribe("1", "2"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	book, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "1", book.AssetID)
+	other, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "999", other.AssetID)
+
+	change, ok := nextMarketEvent(t, stream).(*PriceChangeEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "0.50", change.PriceChanges[0].Price)
+
+	local, ok := stream.Book("1")
+	assert.True(t, ok)
+	assert.Equal(t, updatedBids, local.Bids)
+	assert.Equal(t, "server-hash", local.Hash)
+
+	// Events for assets that aren't subscribed don't create a book
+	_, ok = stream.Book("999")
+	assert.False(t, ok)
+
+	_, ok = nextMarketEvent(t, stream).(*TickSizeChangeEvent)
+	assert.True(t, ok)
+	_, ok = nextMarketEvent(t, stream).(*LastTradePriceEvent)
+	assert.True(t, ok)
+
+	local, _ = stream.Book("1")
+	assert.Equal(t, TickSize0001, local.TickSize)
+	assert.Equal(t, "0.52", local.LastTradePrice)
+
+	// The tick size change also refreshes the client cache
+	tickSize, ok := client.cachedTickSize("1")
+	assert.True(t, ok)
+	assert.Equal(t, TickSize0001, tickSize)
+	close(checked)
+
+	_, ok = nextMarketEvent(t, stream).(*PriceChangeEvent)
+	assert.True(t, ok)
+	resync, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "snapshot", resync.Hash)
+
+	local, _ = stream.Book("1")
+	assert.Equal(t, []OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.50", Size: "10"}}, local.Bids)
+	assert.Equal(t, "unexpected", local.Hash)
+
+	local, ok = stream.Book("2")
+	assert.True(t, ok)
+	assert.Equal(t, snapshot.Bids, local.Bids)
+	assert.Equal(t, snapshot.Asks, local.Asks)
+
+	assert.NoError(t, stream.Close())
+	_, open := <-stream.Events()
+	assert.False(t, open)
+	assert.NoError(t, stream.Err())
+}
+
+func TestMarketStreamCoalescesResnapshots(t *testing.T) {
+	snapshot := OrderBookSummary{
+		Market:    "0xmarket",
+		AssetID:   "1",
+		Timestamp: "1700000000200",
+		Bids:      []OrderSummary{{Price: "0.47", Size: "5"}},
+		Asks:      []OrderSummary{{Price: "0.51", Size: "5"}},
+	}
+
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+
+		// Deltas for a book the stream doesn't have yet, around the snapshot time
+		for i, change := range []PriceChange{
+			{AssetID: "1", Price: "0.47", Size: "1", Side: SideBuy},
+			{AssetID: "1", Price: "0.48", Size: "7", Side: SideBuy},
+			{AssetID: "1", Price: "0.51", Size: "0", Side: SideSell, Hash: "latest"},
+		} {
+			conn.WriteJSON(PriceChangeEvent{
+				EventType:    EventTypePriceChange,
+				Market:       "0xmarket",
+				Timestamp:    []string{"1700000000100", "1700000000300", "1700000000400"}[i],
+				PriceChanges: []PriceChange{change},
+			})
+		}
+
+		conn.ReadMessage()
+	}
+
+	// The snapshot is held back until all the deltas were read, which the
+	// read loop must not wait for
+	var mu sync.Mutex
+	calls := 0
+	read := make(chan struct{})
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		<-read
+		json.NewEncoder(w).Encode(snapshot)
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	stream := NewMarketStream(wsURL, client)
+	assert.NoError(t, stream.Subscribe("1"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	for i := 0; i < 3; i++ {
+		_, ok := nextMarketEvent(t, stream).(*PriceChangeEvent)
+		assert.True(t, ok)
+	}
+	close(read)
+
+	book, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, snapshot.Bids, book.Bids)
+
+	mu.Lock()
+	assert.Equal(t, 1, calls)
+	mu.Unlock()
+
+	// Deltas newer than the snapshot are applied on top of it
+	local, ok := stream.Book("1")
+	assert.True(t, ok)
+	assert.Equal(t, []OrderSummary{{Price: "0.47", Size: "5"}, {Price: "0.48", Size: "7"}}, local.Bids)
+	assert.Empty(t, local.Asks)
+	assert.Equal(t, "1700000000400", local.Timestamp)
+	assert.Equal(t, "latest", local.Hash)
+}
+
+func TestMarketStreamDetectsGaps(t *testing.T) {
+	// The server side books, kept with the same code as the stream's
+	models := map[string]*OrderBookSummary{
+		"1": {
+			Market:    "0xmarket",
+			AssetID:   "1",
+			Timestamp: "1700000001000",
+			Bids:      []OrderSummary{{Price: "0.48", Size: "100"}},
+			Asks:      []OrderSummary{{Price: "0.52", Size: "50"}},
+		},
+		"2": {
+			Market:    "0xmarket",
+			AssetID:   "2",
+			Timestamp: "1700000002000",
+			Bids:      []OrderSummary{{Price: "0.30", Size: "10"}},
+		},
+	}
+	var mu sync.Mutex
+
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+
+		mu.Lock()
+		for _, id := range []string{"1", "2"} {
+			model := models[id]
+			conn.WriteJSON(BookEvent{EventType: EventTypeBook, AssetID: id, Market: model.Market,
+				Timestamp: model.Timestamp, Bids: model.Bids, Asks: model.Asks, Hash: "book-" + id})
+		}
+
+		// Deltas in order apply cleanly, and their hash is kept on the book
+		for i, change := range []PriceChange{
+			{AssetID: "1", Price: "0.49", Size: "10", Side: SideBuy},
+			{AssetID: "1", Price: "0.51", Size: "5", Side: SideSell},
+			{AssetID: "1", Price: "0.48", Size: "0", Side: SideBuy},
+		} {
+			model := models["1"]
+			model.Timestamp = fmt.Sprintf("17000000011%02d", i)
+			assert.NoError(t, applyPriceChange(model, change))
+			change.Hash = fmt.Sprintf("delta-%d", i)
+			model.Hash = change.Hash
+			conn.WriteJSON(PriceChangeEvent{EventType: EventTypePriceChange, Market: model.Market,
+				Timestamp: model.Timestamp, PriceChanges: []PriceChange{change}})
+		}
+
+		// A delta older than the book
+		conn.WriteJSON(PriceChangeEvent{EventType: EventTypePriceChange, Market: "0xmarket",
+			Timestamp: "1700000001900", PriceChanges: []PriceChange{{AssetID: "2", Price: "0.31", Size: "1", Side: SideBuy}}})
+		mu.Unlock()
+
+		conn.ReadMessage()
+	}
+
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "2", r.URL.Query().Get("token_id"))
+		model := *models["2"]
+		model.Hash = "snapshot-2"
+		json.NewEncoder(w).Encode(model)
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	stream := NewMarketStream(wsURL, client)
+	assert.NoError(t, stream.Subscribe("1", "2"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	for i := 0; i < 2; i++ {
+		_, ok := nextMarketEvent(t, stream).(*BookEvent)
+		assert.True(t, ok)
+	}
+	for i := 0; i < 4; i++ {
+		_, ok := nextMarketEvent(t, stream).(*PriceChangeEvent)
+		assert.True(t, ok)
+	}
+
+	// Only the book that received the stale delta is fetched again
+	snapshot, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "2", snapshot.AssetID)
+	models["2"].Hash = "snapshot-2"
+
+	mu.Lock()
+	defer mu.Unlock()
+	for id, model := range models {
+		local, ok := stream.Book(id)
+		assert.True(t, ok)
+		assert.Equal(t, model.Bids, local.Bids)
+		assert.Equal(t, model.Asks, local.Asks)
+		assert.Equal(t, model.Timestamp, local.Timestamp)
+		assert.Equal(t, model.Hash, local.Hash)
+	}
+}
+
+func TestMarketStreamReplaysEveryBufferedChange(t *testing.T) {
+	client := NewClobClient("http://localhost", 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	stream := NewMarketStream("", client)
+	assert.NoError(t, stream.Subscribe("1"))
+
+	// Both changes of an event for a missing book are buffered, as is a later
+	// event sharing its timestamp
+	stale := stream.apply(&PriceChangeEvent{EventType: EventTypePriceChange, Timestamp: "200", PriceChanges: []PriceChange{
+		{AssetID: "1", Price: "0.40", Size: "10", Side: SideBuy},
+		{AssetID: "1", Price: "0.60", Size: "20", Side: SideSell},
+	}})
+	assert.Equal(t, []string{"1"}, stale)
+	stream.apply(&PriceChangeEvent{EventType: EventTypePriceChange, Timestamp: "200", PriceChanges: []PriceChange{
+		{AssetID: "1", Price: "0.41", Size: "5", Side: SideBuy},
+	}})
+
+	snapshot := stream.installSnapshot("1", &OrderBookSummary{AssetID: "1", Timestamp: "100"})
+	assert.NotNil(t, snapshot)
+
+	book, ok := stream.Book("1")
+	assert.True(t, ok)
+	assert.Equal(t, []OrderSummary{{Price: "0.40", Size: "10"}, {Price: "0.41", Size: "5"}}, book.Bids)
+	assert.Equal(t, []OrderSummary{{Price: "0.60", Size: "20"}}, book.Asks)
+	assert.Equal(t, "200", book.Timestamp)
+
+	// A change that fails to apply buffers the whole event, including the
+	// changes already applied to the dropped book
+	stale = stream.apply(&PriceChangeEvent{EventType: EventTypePriceChange, Timestamp: "300", PriceChanges: []PriceChange{
+		{AssetID: "1", Price: "0.42", Size: "1", Side: SideBuy},
+		{AssetID: "1", Price: "0.59", Size: "1", Side: "NONE"},
+		{AssetID: "1", Price: "0.58", Size: "2", Side: SideSell},
+	}})
+	assert.Equal(t, []string{"1"}, stale)
+	stream.installSnapshot("1", &OrderBookSummary{AssetID: "1", Timestamp: "250"})
+	book, _ = stream.Book("1")
+	assert.Equal(t, []OrderSummary{{Price: "0.42", Size: "1"}}, book.Bids)
+	assert.Equal(t, []OrderSummary{{Price: "0.58", Size: "2"}}, book.Asks)
+}
+
+func TestMarketStreamBookEventsKeepMarketFields(t *testing.T) {
+	client := NewClobClient("http://localhost", 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	client.storeTickSize("1", TickSize0001)
+	client.storeNegRisk("1", true)
+
+	stream := NewMarketStream("", client)
+	assert.NoError(t, stream.Subscribe("1"))
+
+	// The first book takes what the client knows about the market
+	stream.apply(&BookEvent{EventType: EventTypeBook, AssetID: "1", Timestamp: "1"})
+	book, ok := stream.Book("1")
+	assert.True(t, ok)
+	assert.Equal(t, TickSize0001, book.TickSize)
+	assert.True(t, book.NegRisk)
+
+	// Later ones keep the fields of the previous book, as a REST snapshot sets them
+	stream.books["1"].MinOrderSize = "5"
+	stream.apply(&LastTradePriceEvent{EventType: EventTypeLastTradePrice, AssetID: "1", Price: "0.52"})
+	stream.apply(&BookEvent{EventType: EventTypeBook, AssetID: "1", Timestamp: "2"})
+	book, _ = stream.Book("1")
+	assert.Equal(t, "2", book.Timestamp)
+	assert.Equal(t, TickSize0001, book.TickSize)
+	assert.Equal(t, "5", book.MinOrderSize)
+	assert.True(t, book.NegRisk)
+	assert.Equal(t, "0.52", book.LastTradePrice)
+}
+
+func TestMarketStreamWithoutReconnect(t *testing.T) {
+	handle := func(conn *websocket.Conn) {
+		conn.ReadMessage()
+	}
+
+	server, wsURL := newWSServer(t, handle, nil)
+	defer server.Close()
+
+	stream := NewMarketStream(wsURL, nil)
+	stream.SetReconnectPolicy(ReconnectPolicy{Disabled: true})
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	disconnected, ok := nextMarketEvent(t, stream).(*DisconnectedEvent)
+	assert.True(t, ok)
+	assert.Error(t, disconnected.Err)
+
+	select {
+	case _, open := <-stream.Events():
+		assert.False(t, open)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not end")
+	}
+	assert.Error(t, stream.Err())
+}
+
+func TestMarketStreamReconnectsAndResyncs(t *testing.T) {
+	snapshot := OrderBookSummary{
+		Market:    "0xmarket",
+		AssetID:   "1",
+		Timestamp: "1700000000500",
+		Bids:      []OrderSummary{{Price: "0.47", Size: "5"}},
+		Asks:      []OrderSummary{{Price: "0.51", Size: "5"}},
+		Hash:      "snapshot",
+	}
+
+	var mu sync.Mutex
+	var subscriptions []map[string]interface{}
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+
+		mu.Lock()
+		subscriptions = append(subscriptions, subscription)
+		first := len(subscriptions) == 1
+		mu.Unlock()
+
+		if first {
+			conn.WriteJSON(BookEvent{EventType: EventTypeBook, AssetID: "1", Market: "0xmarket", Bids: snapshot.Asks})
+			// Drop the connection
+			return
+		}
+		conn.ReadMessage()
+	}
+
+	// Books are refetched in one batch
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetOrderBooks, r.URL.Path)
+		var params []BookParams
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		assert.Equal(t, []BookParams{{TokenID: "1"}}, params)
+		json.NewEncoder(w).Encode([]OrderBookSummary{snapshot})
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	stream := NewMarketStream(wsURL, client)
+	stream.SetReconnectPolicy(ReconnectPolicy{BaseDelay: 10 * time.Millisecond})
+	assert.NoError(t, stream.Subscribe("1"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	_, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	_, ok = nextMarketEvent(t, stream).(*DisconnectedEvent)
+	assert.True(t, ok)
+
+	// Books are refetched before the stream reports it is back in sync
+	book, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "snapshot", book.Hash)
+	resynced, ok := nextMarketEvent(t, stream).(*ResyncedEvent)
+	assert.True(t, ok)
+	assert.NoError(t, resynced.Err)
+
+	local, ok := stream.Book("1")
+	assert.True(t, ok)
+	assert.Equal(t, snapshot.Bids, local.Bids)
+
+	mu.Lock()
+	assert.Len(t, subscriptions, 2)
+	assert.Equal(t, subscriptions[0], subscriptions[1])
+	mu.Unlock()
+
+	assert.NoError(t, stream.Close())
+	assert.NoError(t, stream.Err())
+}
+
+func TestMarketStreamReadsWhileResyncing(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+
+		mu.Lock()
+		connections++
+		first := connections == 1
+		mu.Unlock()
+
+		if first {
+			return
+		}
+		conn.WriteJSON(BookEvent{EventType: EventTypeBook, AssetID: "1", Market: "0xmarket", Hash: "live"})
+		conn.ReadMessage()
+	}
+
+	// The snapshots are only served once the live book event got through
+	release := make(chan struct{})
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		json.NewEncoder(w).Encode([]OrderBookSummary{{AssetID: "1", Hash: "snapshot"}})
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	stream := NewMarketStream(wsURL, client)
+	stream.SetReconnectPolicy(ReconnectPolicy{BaseDelay: 10 * time.Millisecond})
+	assert.NoError(t, stream.Subscribe("1"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	_, ok := nextMarketEvent(t, stream).(*DisconnectedEvent)
+	assert.True(t, ok)
+
+	book, ok := nextMarketEvent(t, stream).(*BookEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "live", book.Hash)
+	close(release)
+
+	// The book event is newer than the snapshot, which is dropped
+	resynced, ok := nextMarketEvent(t, stream).(*ResyncedEvent)
+	assert.True(t, ok)
+	assert.NoError(t, resynced.Err)
+
+	local, ok := stream.Book("1")
+	assert.True(t, ok)
+	assert.Equal(t, "live", local.Hash)
+}
+
+func TestReconnectPolicyAttempts(t *testing.T) {
+	assert.True(t, DefaultReconnectPolicy().allows(1000))
+	assert.False(t, ReconnectPolicy{Disabled: true}.allows(0))
+
+	limited := ReconnectPolicy{MaxAttempts: 2}
+	assert.True(t, limited.allows(1))
+	assert.False(t, limited.allows(2))
+}
+
+func TestMarketStreamHeartbeat(t *testing.T) {
+	pings := make(chan string, 10)
+	handle := func(conn *websocket.Conn) {
+		conn.ReadMessage()
+
+		// Answer the first heartbeat only, then go silent
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		pings <- string(data)
+		conn.WriteMessage(websocket.TextMessage, []byte("PONG"))
+		conn.ReadMessage()
+	}
+
+	server, wsURL := newWSServer(t, handle, nil)
+	defer server.Close()
+
+	stream := NewMarketStream(wsURL, nil)
+	stream.SetReconnectPolicy(ReconnectPolicy{Disabled: true})
+	stream.SetPingInterval(20 * time.Millisecond)
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	// The missing replies fail the connection
+	disconnected, ok := nextMarketEvent(t, stream).(*DisconnectedEvent)
+	assert.True(t, ok)
+	assert.Error(t, disconnected.Err)
+	assert.Equal(t, "PING", <-pings)
+}
+
+func TestSetLevel(t *testing.T) {
+	bids := func() []OrderSummary {
+		return []OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.5", Size: "20"}}
+	}
+
+	tests := []struct {
+		name     string
+		price    string
+		size     string
+		expected []OrderSummary
+	}{
+		{"update", "0.50", "25", []OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.5", Size: "25"}}},
+		{"remove", "0.48", "0", []OrderSummary{{Price: "0.5", Size: "20"}}},
+		{"insert between", "0.49", "7", []OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.49", Size: "7"}, {Price: "0.5", Size: "20"}}},
+		{"insert first", "0.40", "7", []OrderSummary{{Price: "0.40", Size: "7"}, {Price: "0.48", Size: "100"}, {Price: "0.5", Size: "20"}}},
+		{"insert last", "0.51", "7", []OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.5", Size: "20"}, {Price: "0.51", Size: "7"}}},
+		{"remove missing level", "0.49", "0", bids()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels, err := setLevel(bids(), tt.price, tt.size, true)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, levels)
+		})
+	}
+
+	// Asks are kept in descending order
+	asks, err := setLevel([]OrderSummary{{Price: "0.55", Size: "1"}, {Price: "0.52", Size: "1"}}, "0.53", "2", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []OrderSummary{{Price: "0.55", Size: "1"}, {Price: "0.53", Size: "2"}, {Price: "0.52", Size: "1"}}, asks)
+
+	_, err = setLevel(bids(), "abc", "1", true)
+	assert.Error(t, err)
+}
+
+func TestDecodeMarketEventLegacyFormats(t *testing.T) {
+	event, err := decodeMarketEvent([]byte(`{"event_type":"book","asset_id":"1","buys":[{"price":"0.4","size":"1"}],"sells":[{"price":"0.6","size":"2"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &BookEvent{
+		EventType: EventTypeBook,
+		AssetID:   "1",
+		Bids:      []OrderSummary{{Price: "0.4", Size: "1"}},
+		Asks:      []OrderSummary{{Price: "0.6", Size: "2"}},
+	}, event)
+
+	event, err = decodeMarketEvent([]byte(`{"event_type":"price_change","asset_id":"1","hash":"h","timestamp":"5",` +
+		`"changes":[{"price":"0.4","size":"1","side":"BUY"},{"price":"0.6","size":"0","side":"SELL"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &PriceChangeEvent{
+		EventType: EventTypePriceChange,
+		Timestamp: "5",
+		PriceChanges: []PriceChange{
+			{AssetID: "1", Price: "0.4", Size: "1", Side: SideBuy},
+			{AssetID: "1", Price: "0.6", Size: "0", Side: SideSell, Hash: "h"},
+		},
+	}, event)
+
+	event, err = decodeMarketEvent([]byte(`{"event_type":"new_feature"}`))
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestMarketStreamConnectAfterClose(t *testing.T) {
+	stream := NewMarketStream("ws://127.0.0.1:1/ws", nil)
+	assert.NoError(t, stream.Close())
+	assert.Error(t, stream.Connect())
+	assert.NoError(t, stream.Close())
+
+	_, open := <-stream.Events()
+	assert.False(t, open)
+}
@@ -0,0 +1,134 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetMarket retrieves a market by condition ID
+func (c *ClobClient) GetMarket(conditionID string) (*Market, error) {
+	return c.GetMarketWithContext(context.Background(), conditionID)
+}
+
+// GetMarketWithContext is like GetMarket but bound to ctx
+func (c *ClobClient) GetMarketWithContext(ctx context.Context, conditionID string) (*Market, error) {
+	url := c.Host + EndpointGetMarketByID + conditionID
+
+	resp, err := c.HTTPClient.GetWithContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market: %w", err)
+	}
+
+	var market Market
+	if err := json.Unmarshal(resp, &market); err != nil {
+		return nil, fmt.Errorf("failed to parse market: %w", err)
+	}
+
+	return &market, nil
+}
+
+// GetMarketsPage retrieves one page of markets. Pass InitialCursor (or "") for
+// the first page and the returned NextCursor for the following ones.
+func (c *ClobClient) GetMarketsPage(cursor string) (*Page[Market], error) {
+	return c.GetMarketsPageWithContext(context.Background(), cursor)
+}
+
+// GetMarketsPageWithContext is like GetMarketsPage but bound to ctx
+func (c *ClobClient) GetMarketsPageWithContext(ctx context.Context, cursor string) (*Page[Market], error) {
+	page, err := getPublicPage[Market](ctx, c, EndpointGetMarkets, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+	return page, nil
+}
+
+// MarketsIterator returns an iterator over all markets
+func (c *ClobClient) MarketsIterator() *PageIterator[Market] {
+	return newPageIterator(func(ctx context.Context, cursor string) (*Page[Market], error) {
+		return c.GetMarketsPageWithContext(ctx, cursor)
+	})
+}
+
+// GetMarkets retrieves every page of markets. MarketsIterator walks the same
+// list lazily.
+func (c *ClobClient) GetMarkets() ([]Market, error) {
+	return c.GetMarketsWithContext(context.Background())
+}
+
+// GetMarketsWithContext is like GetMarkets but bound to ctx
+func (c *ClobClient) GetMarketsWithContext(ctx context.Context) ([]Market, error) {
+	return c.MarketsIterator().collect(ctx)
+}
+
+// GetSamplingMarketsPage retrieves one page of the markets currently eligible
+// for liquidity rewards
+func (c *ClobClient) GetSamplingMarketsPage(cursor string) (*Page[Market], error) {
+	return c.GetSamplingMarketsPageWithContext(context.Background(), cursor)
+}
+
+// GetSamplingMarketsPageWithContext is like GetSamplingMarketsPage but bound to ctx
+func (c *ClobClient) GetSamplingMarketsPageWithContext(ctx context.Context, cursor string) (*Page[Market], error) {
+	page, err := getPublicPage[Market](ctx, c, EndpointGetSamplingMarkets, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sampling markets: %w", err)
+	}
+	return page, nil
+}
+
+// SamplingMarketsIterator returns an iterator over all markets eligible for liquidity rewards
+func (c *ClobClient) SamplingMarketsIterator() *PageIterator[Market] {
+	return newPageIterator(func(ctx context.Context, cursor string) (*Page[Market], error) {
+		return c.GetSamplingMarketsPageWithContext(ctx, cursor)
+	})
+}
+
+// GetSimplifiedMarketsPage retrieves one page of markets in simplified form
+func (c *ClobClient) GetSimplifiedMarketsPage(cursor string) (*Page[SimplifiedMarket], error) {
+	return c.GetSimplifiedMarketsPageWithContext(context.Background(), cursor)
+}
+
+// GetSimplifiedMarketsPageWithContext is like GetSimplifiedMarketsPage but bound to ctx
+func (c *ClobClient) GetSimplifiedMarketsPageWithContext(
+	ctx context.Context,
+	cursor string,
+) (*Page[SimplifiedMarket], error) {
+	page, err := getPublicPage[SimplifiedMarket](ctx, c, EndpointGetSimplifiedMarkets, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get simplified markets: %w", err)
+	}
+	return page, nil
+}
+
+// SimplifiedMarketsIterator returns an iterator over all markets in simplified form
+func (c *ClobClient) SimplifiedMarketsIterator() *PageIterator[SimplifiedMarket] {
+	return newPageIterator(func(ctx context.Context, cursor string) (*Page[SimplifiedMarket], error) {
+		return c.GetSimplifiedMarketsPageWithContext(ctx, cursor)
+	})
+}
+
+// GetSamplingSimplifiedMarketsPage retrieves one page of the markets eligible
+// for liquidity rewards in simplified form
+func (c *ClobClient) GetSamplingSimplifiedMarketsPage(cursor string) (*Page[SimplifiedMarket], error) {
+	return c.GetSamplingSimplifiedMarketsPageWithContext(context.Background(), cursor)
+}
+
+// GetSamplingSimplifiedMarketsPageWithContext is like GetSamplingSimplifiedMarketsPage but bound to ctx
+func (c *ClobClient) GetSamplingSimplifiedMarketsPageWithContext(
+	ctx context.Context,
+	cursor string,
+) (*Page[SimplifiedMarket], error) {
+	page, err := getPublicPage[SimplifiedMarket](ctx, c, EndpointGetSamplingSimplified, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sampling simplified markets: %w", err)
+	}
+	return page, nil
+}
+
+// SamplingSimplifiedMarketsIterator returns an iterator over all markets
+// eligible for liquidity rewards in simplified form
+func (c *ClobClient) SamplingSimplifiedMarketsIterator() *PageIterator[SimplifiedMarket] {
+	return newPageIterator(func(ctx context.Context, cursor string) (*Page[SimplifiedMarket], error) {
+		return c.GetSamplingSimplifiedMarketsPageWithContext(ctx, cursor)
+	})
+}
@@ -0,0 +1,101 @@
+package clobclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMarketJSON = `{
+	"condition_id": "0xcond",
+	"question_id": "0xquestion",
+	"question": "Will it rain tomorrow?",
+	"market_slug": "will-it-rain-tomorrow",
+	"tokens": [
+		{"token_id": "111", "outcome": "Yes", "price": 0.55, "winner": false},
+		{"token_id": "222", "outcome": "No", "price": 0.45, "winner": false}
+	],
+	"rewards": {
+		"rates": [{"asset_address": "0xusdc", "rewards_daily_rate": 25}],
+		"min_size": 50,
+		"max_spread": 3.5
+	},
+	"minimum_order_size": 5,
+	"minimum_tick_size": 0.01,
+	"end_date_iso": "2026-12-31T00:00:00Z",
+	"active": true,
+	"closed": false,
+	"accepting_orders": true,
+	"neg_risk": true
+}`
+
+func TestGetMarket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetMarketByID+"0xcond", r.URL.Path)
+		w.Write([]byte(testMarketJSON))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	market, err := client.GetMarket("0xcond")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xcond", market.ConditionID)
+	assert.Equal(t, []Token{
+		{TokenID: "111", Outcome: "Yes", Price: 0.55},
+		{TokenID: "222", Outcome: "No", Price: 0.45},
+	}, market.Tokens)
+	assert.Equal(t, Rewards{
+		Rates:     []RewardRate{{AssetAddress: "0xusdc", RewardsDailyRate: 25}},
+		MinSize:   50,
+		MaxSpread: 3.5,
+	}, market.Rewards)
+	assert.Equal(t, 0.01, market.MinimumTickSize)
+	assert.Equal(t, "2026-12-31T00:00:00Z", market.EndDateISO)
+	assert.True(t, market.AcceptingOrders)
+	assert.True(t, market.NegRisk)
+}
+
+func TestMarketIteratorsFollowCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Market lists are public
+		assert.Empty(t, r.Header.Get("POLY_SIGNATURE"))
+
+		if r.URL.Query().Get("next_cursor") == InitialCursor {
+			w.Write([]byte(`{"next_cursor":"MQ==","data":[{"condition_id":"` + r.URL.Path + `-0"}]}`))
+			return
+		}
+		w.Write([]byte(`{"next_cursor":"LTE=","data":[{"condition_id":"` + r.URL.Path + `-1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	ctx := context.Background()
+
+	markets := map[string]*PageIterator[Market]{
+		EndpointGetMarkets:         client.MarketsIterator(),
+		EndpointGetSamplingMarkets: client.SamplingMarketsIterator(),
+	}
+	for endpoint, it := range markets {
+		got, err := it.collect(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []Market{{ConditionID: endpoint + "-0"}, {ConditionID: endpoint + "-1"}}, got)
+	}
+
+	all, err := client.GetMarkets()
+	assert.NoError(t, err)
+	assert.Equal(t, []Market{{ConditionID: EndpointGetMarkets + "-0"}, {ConditionID: EndpointGetMarkets + "-1"}}, all)
+
+	simplified := map[string]*PageIterator[SimplifiedMarket]{
+		EndpointGetSimplifiedMarkets:  client.SimplifiedMarketsIterator(),
+		EndpointGetSamplingSimplified: client.SamplingSimplifiedMarketsIterator(),
+	}
+	for endpoint, it := range simplified {
+		got, err := it.collect(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []SimplifiedMarket{{ConditionID: endpoint + "-0"}, {ConditionID: endpoint + "-1"}}, got)
+	}
+}
@@ -0,0 +1,185 @@
+package clobclient
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// PriceLevel is an order book level parsed to numbers
+type PriceLevel struct {
+	Price float64
+	Size  float64
+}
+
+// bookLevel is an order book level parsed to exact decimals
+type bookLevel struct {
+	price *big.Rat
+	size  *big.Rat
+}
+
+// sortBookLevels parses levels and sorts them best first: ascending prices
+// for asks, descending for bids
+func sortBookLevels(levels []OrderSummary, descending bool) ([]bookLevel, error) {
+	sorted := make([]bookLevel, 0, len(levels))
+	for _, level := range levels {
+		price, err := decimalFromString(level.Price)
+		if err != nil {
+			return nil, fmt.Errorf("invalid book level price: %w", err)
+		}
+		size, err := decimalFromString(level.Size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid book level size: %w", err)
+		}
+		sorted = append(sorted, bookLevel{price: price, size: size})
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return sorted[i].price.Cmp(sorted[j].price) > 0
+		}
+		return sorted[i].price.Cmp(sorted[j].price) < 0
+	})
+
+	return sorted, nil
+}
+
+// bidLevels returns the bids sorted best (highest) first
+func (b *OrderBookSummary) bidLevels() ([]bookLevel, error) {
+	return sortBookLevels(b.Bids, true)
+}
+
+// askLevels returns the asks sorted best (lowest) first
+func (b *OrderBookSummary) askLevels() ([]bookLevel, error) {
+	return sortBookLevels(b.Asks, false)
+}
+
+// priceLevels converts exact levels to PriceLevels
+func priceLevels(levels []bookLevel) []PriceLevel {
+	converted := make([]PriceLevel, len(levels))
+	for i, level := range levels {
+		converted[i].Price, _ = level.price.Float64()
+		converted[i].Size, _ = level.size.Float64()
+	}
+	return converted
+}
+
+// SortedBids returns the bids sorted best (highest price) first. The server
+// sends them in the opposite order.
+func (b *OrderBookSummary) SortedBids() ([]PriceLevel, error) {
+	levels, err := b.bidLevels()
+	if err != nil {
+		return nil, err
+	}
+	return priceLevels(levels), nil
+}
+
+// SortedAsks returns the asks sorted best (lowest price) first. The server
+// sends them in the opposite order.
+func (b *OrderBookSummary) SortedAsks() ([]PriceLevel, error) {
+	levels, err := b.askLevels()
+	if err != nil {
+		return nil, err
+	}
+	return priceLevels(levels), nil
+}
+
+// bestLevel returns the first of levels, or ErrEmptyBook. It takes the
+// results of bidLevels or askLevels directly.
+func bestLevel(levels []bookLevel, err error) (*bookLevel, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return nil, ErrEmptyBook
+	}
+	return &levels[0], nil
+}
+
+// BestBid returns the highest bid price
+func (b *OrderBookSummary) BestBid() (float64, error) {
+	bid, err := bestLevel(b.bidLevels())
+	if err != nil {
+		return 0, err
+	}
+	price, _ := bid.price.Float64()
+	return price, nil
+}
+
+// BestAsk returns the lowest ask price
+func (b *OrderBookSummary) BestAsk() (float64, error) {
+	ask, err := bestLevel(b.askLevels())
+	if err != nil {
+		return 0, err
+	}
+	price, _ := ask.price.Float64()
+	return price, nil
+}
+
+// bestPrices returns the best bid and ask prices as exact decimals
+func (b *OrderBookSummary) bestPrices() (*big.Rat, *big.Rat, error) {
+	bid, err := bestLevel(b.bidLevels())
+	if err != nil {
+		return nil, nil, err
+	}
+	ask, err := bestLevel(b.askLevels())
+	if err != nil {
+		return nil, nil, err
+	}
+	return bid.price, ask.price, nil
+}
+
+// Spread returns the difference between the best ask and the best bid
+func (b *OrderBookSummary) Spread() (float64, error) {
+	bid, ask, err := b.bestPrices()
+	if err != nil {
+		return 0, err
+	}
+	spread, _ := new(big.Rat).Sub(ask, bid).Float64()
+	return spread, nil
+}
+
+// Mid returns the midpoint between the best bid and the best ask
+func (b *OrderBookSummary) Mid() (float64, error) {
+	bid, ask, err := b.bestPrices()
+	if err != nil {
+		return 0, err
+	}
+	sum := new(big.Rat).Add(ask, bid)
+	mid, _ := sum.Quo(sum, big.NewRat(2, 1)).Float64()
+	return mid, nil
+}
+
+// totalSize sums the sizes of levels
+func totalSize(levels []OrderSummary) (float64, error) {
+	total := new(big.Rat)
+	for _, level := range levels {
+		size, err := decimalFromString(level.Size)
+		if err != nil {
+			return 0, fmt.Errorf("invalid book level size: %w", err)
+		}
+		total.Add(total, size)
+	}
+	depth, _ := total.Float64()
+	return depth, nil
+}
+
+// BidDepth returns the total size of all bids, in shares
+func (b *OrderBookSummary) BidDepth() (float64, error) {
+	return totalSize(b.Bids)
+}
+
+// AskDepth returns the total size of all asks, in shares
+func (b *OrderBookSummary) AskDepth() (float64, error) {
+	return totalSize(b.Asks)
+}
+
+// MinOrderSizeValue returns the minimum order size of the market, in shares
+func (b *OrderBookSummary) MinOrderSizeValue() (float64, error) {
+	size, err := decimalFromString(b.MinOrderSize)
+	if err != nil {
+		return 0, err
+	}
+	value, _ := size.Float64()
+	return value, nil
+}
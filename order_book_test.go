@@ -0,0 +1,83 @@
+package clobclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBookSortedLevels(t *testing.T) {
+	book := testBook()
+
+	bids, err := book.SortedBids()
+	assert.NoError(t, err)
+	assert.Equal(t, []PriceLevel{{0.50, 20}, {0.49, 50}, {0.48, 100}}, bids)
+
+	asks, err := book.SortedAsks()
+	assert.NoError(t, err)
+	assert.Equal(t, []PriceLevel{{0.52, 20}, {0.53, 50}, {0.55, 100}}, asks)
+
+	// The raw levels are left in server order
+	assert.Equal(t, "0.48", book.Bids[0].Price)
+}
+
+func TestOrderBookStatistics(t *testing.T) {
+	book := testBook()
+	book.MinOrderSize = "5"
+
+	bid, err := book.BestBid()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.50, bid)
+
+	ask, err := book.BestAsk()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.52, ask)
+
+	// Computed exactly: 0.52 - 0.50 is not 0.020000000000000018
+	spread, err := book.Spread()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.02, spread)
+
+	mid, err := book.Mid()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.51, mid)
+
+	bidDepth, err := book.BidDepth()
+	assert.NoError(t, err)
+	assert.Equal(t, 170.0, bidDepth)
+
+	askDepth, err := book.AskDepth()
+	assert.NoError(t, err)
+	assert.Equal(t, 170.0, askDepth)
+
+	minSize, err := book.MinOrderSizeValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, minSize)
+}
+
+func TestOrderBookStatisticsOnEmptySide(t *testing.T) {
+	book := testBook()
+	book.Asks = nil
+
+	_, err := book.BestAsk()
+	assert.ErrorIs(t, err, ErrEmptyBook)
+	_, err = book.Spread()
+	assert.ErrorIs(t, err, ErrEmptyBook)
+	_, err = book.Mid()
+	assert.ErrorIs(t, err, ErrEmptyBook)
+
+	depth, err := book.AskDepth()
+	assert.NoError(t, err)
+	assert.Zero(t, depth)
+
+	book.Bids = []OrderSummary{{Price: "abc", Size: "1"}}
+	_, err = book.BestBid()
+	assert.Error(t, err)
+}
+
+func TestOrderBookTickSizeIsTyped(t *testing.T) {
+	var book OrderBookSummary
+	assert.NoError(t, json.Unmarshal([]byte(`{"tick_size":"0.001"}`), &book))
+	assert.Equal(t, TickSize0001, book.TickSize)
+}
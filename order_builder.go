@@ -2,6 +2,7 @@ package clobclient
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -36,32 +37,96 @@ func (b *OrderBuilder) BuildOrder(
 	userOrder *UserOrder,
 	options *CreateOrderOptions,
 ) (*SignedOrder, error) {
-	// Get address
-	address, err := GetAddressFromPrivateKey(b.PrivateKey)
+	// Get rounding config based on tick size
+	roundConfig := getRoundConfig(options.TickSize)
+
+	// Calculate amounts
+	makerAmount, takerAmount, err := calculateOrderAmounts(
+		userOrder.Price,
+		userOrder.Size,
+		userOrder.Side,
+		roundConfig,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get address: %w", err)
+		return nil, fmt.Errorf("failed to calculate amounts: %w", err)
 	}
 
-	// Determine maker and signer
-	maker := address
-	if b.FunderAddress != nil {
-		maker = *b.FunderAddress
+	return b.signOrder(&orderData{
+		tokenID:     userOrder.TokenID,
+		side:        userOrder.Side,
+		makerAmount: makerAmount,
+		takerAmount: takerAmount,
+		feeRateBps:  userOrder.FeeRateBps,
+		nonce:       userOrder.Nonce,
+		expiration:  userOrder.Expiration,
+		taker:       userOrder.Taker,
+	}, options)
+}
+
+// BuildMarketOrder creates and signs a market order. The price must be set;
+// ClobClient.CreateMarketOrder derives it from the order book.
+func (b *OrderBuilder) BuildMarketOrder(
+	userMarketOrder *UserMarketOrder,
+	options *CreateOrderOptions,
+) (*SignedOrder, error) {
+	if userMarketOrder.Price == nil {
+		return nil, fmt.Errorf("market order price is required")
 	}
 
 	// Get rounding config based on tick size
 	roundConfig := getRoundConfig(options.TickSize)
 
 	// Calculate amounts
-	makerAmount, takerAmount, err := calculateOrderAmounts(
-		userOrder.Price,
-		userOrder.Size,
-		userOrder.Side,
+	makerAmount, takerAmount, err := calculateMarketOrderAmounts(
+		*userMarketOrder.Price,
+		userMarketOrder.Amount,
+		userMarketOrder.Side,
 		roundConfig,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate amounts: %w", err)
 	}
 
+	return b.signOrder(&orderData{
+		tokenID:     userMarketOrder.TokenID,
+		side:        userMarketOrder.Side,
+		makerAmount: makerAmount,
+		takerAmount: takerAmount,
+		feeRateBps:  userMarketOrder.FeeRateBps,
+		nonce:       userMarketOrder.Nonce,
+		taker:       userMarketOrder.Taker,
+	}, options)
+}
+
+// orderData holds the protocol-level fields of an order before signing
+type orderData struct {
+	tokenID     string
+	side        Side
+	makerAmount string
+	takerAmount string
+	feeRateBps  *int
+	nonce       *int64
+	expiration  *int64
+	taker       *string
+}
+
+// signOrder fills in defaults, then creates and signs the order
+func (b *OrderBuilder) signOrder(
+	data *orderData,
+	options *CreateOrderOptions,
+) (*SignedOrder, error) {
+	// Get address
+	address, err := GetAddressFromPrivateKey(b.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	// Determine maker and signer
+	maker := address
+	if b.FunderAddress != nil {
+		maker = *b.FunderAddress
+	}
+
 	// Generate salt
 	salt, err := generateSalt()
 	if err != nil {
@@ -70,23 +135,23 @@ func (b *OrderBuilder) BuildOrder(
 
 	// Set defaults
 	taker := "0x0000000000000000000000000000000000000000"
-	if userOrder.Taker != nil {
-		taker = *userOrder.Taker
+	if data.taker != nil {
+		taker = *data.taker
 	}
 
 	nonce := "0"
-	if userOrder.Nonce != nil {
-		nonce = strconv.FormatInt(*userOrder.Nonce, 10)
+	if data.nonce != nil {
+		nonce = strconv.FormatInt(*data.nonce, 10)
 	}
 
 	expiration := "0"
-	if userOrder.Expiration != nil {
-		expiration = strconv.FormatInt(*userOrder.Expiration, 10)
+	if data.expiration != nil {
+		expiration = strconv.FormatInt(*data.expiration, 10)
 	}
 
 	feeRateBps := "0"
-	if userOrder.FeeRateBps != nil {
-		feeRateBps = strconv.Itoa(*userOrder.FeeRateBps)
+	if data.feeRateBps != nil {
+		feeRateBps = strconv.Itoa(*data.feeRateBps)
 	}
 
 	// Create order
@@ -95,18 +160,19 @@ func (b *OrderBuilder) BuildOrder(
 		Maker:         maker,
 		Signer:        address,
 		Taker:         taker,
-		TokenID:       userOrder.TokenID,
-		MakerAmount:   makerAmount,
-		TakerAmount:   takerAmount,
+		TokenID:       data.tokenID,
+		MakerAmount:   data.makerAmount,
+		TakerAmount:   data.takerAmount,
 		Expiration:    expiration,
 		Nonce:         nonce,
 		FeeRateBps:    feeRateBps,
-		Side:          userOrder.Side,
+		Side:          data.side,
 		SignatureType: b.SignatureType,
 	}
 
-	// Sign the order
-	signature, err := BuildOrderSignature(b.ChainID, b.PrivateKey, order, b.SignatureType)
+	// Sign the order against the exchange the market settles on
+	negRisk := options.NegRisk != nil && *options.NegRisk
+	signature, err := BuildOrderSignatureForExchange(b.ChainID, b.PrivateKey, order, b.SignatureType, negRisk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign order: %w", err)
 	}
@@ -116,66 +182,27 @@ func (b *OrderBuilder) BuildOrder(
 	return order, nil
 }
 
-// BuildMarketOrder creates and signs a market order
-func (b *OrderBuilder) BuildMarketOrder(
-	userMarketOrder *UserMarketOrder,
-	options *CreateOrderOptions,
-) (*SignedOrder, error) {
-	// Convert market order to regular order
-	// For market orders, we use the amount to calculate size
-	var size float64
-	var price float64
-
-	if userMarketOrder.Price != nil {
-		price = *userMarketOrder.Price
-	} else {
-		// If no price specified, use a default based on side
-		if userMarketOrder.Side == SideBuy {
-			price = 1.0 // Buy at max price
-		} else {
-			price = 0.01 // Sell at min price
-		}
-	}
-
-	if userMarketOrder.Side == SideBuy {
-		// For BUY: amount is in USDC, calculate token size
-		size = userMarketOrder.Amount / price
-	} else {
-		// For SELL: amount is in tokens
-		size = userMarketOrder.Amount
-	}
-
-	// Create UserOrder from market order
-	userOrder := &UserOrder{
-		TokenID:    userMarketOrder.TokenID,
-		Price:      price,
-		Size:       size,
-		Side:       userMarketOrder.Side,
-		FeeRateBps: userMarketOrder.FeeRateBps,
-		Nonce:      userMarketOrder.Nonce,
-		Taker:      userMarketOrder.Taker,
-	}
-
-	return b.BuildOrder(userOrder, options)
-}
-
-// getRoundConfig returns the rounding configuration for a tick size
+// getRoundConfig returns the rounding configuration for a tick size. Sizes
+// always have 2 decimals; amounts carry enough decimals to hold price * size
+// exactly, matching the reference client.
 func getRoundConfig(tickSize TickSize) RoundConfig {
 	switch tickSize {
 	case TickSize01:
-		return RoundConfig{Price: 1, Size: 1, Amount: 1}
+		return RoundConfig{Price: 1, Size: 2, Amount: 3}
 	case TickSize001:
-		return RoundConfig{Price: 2, Size: 2, Amount: 2}
+		return RoundConfig{Price: 2, Size: 2, Amount: 4}
 	case TickSize0001:
-		return RoundConfig{Price: 3, Size: 3, Amount: 3}
+		return RoundConfig{Price: 3, Size: 2, Amount: 5}
 	case TickSize00001:
-		return RoundConfig{Price: 4, Size: 4, Amount: 4}
+		return RoundConfig{Price: 4, Size: 2, Amount: 6}
 	default:
-		return RoundConfig{Price: 2, Size: 2, Amount: 2}
+		return RoundConfig{Price: 2, Size: 2, Amount: 4}
 	}
 }
 
-// calculateOrderAmounts calculates maker and taker amounts for an order
+// calculateOrderAmounts calculates maker and taker amounts for an order using
+// exact decimal arithmetic. The size is rounded down, so that the ratio of the
+// two amounts reproduces the tick-aligned price.
 func calculateOrderAmounts(
 	price float64,
 	size float64,
@@ -187,33 +214,108 @@ func calculateOrderAmounts(
 		return "", "", fmt.Errorf("price must be between 0 and 1")
 	}
 
-	// Calculate raw amounts based on side
-	var rawMakerAmount, rawTakerAmount float64
+	rawPrice := roundNormal(decimalFromFloat(price), roundConfig.Price)
+	rawSize := roundDown(decimalFromFloat(size), roundConfig.Size)
+	if rawSize.Sign() <= 0 {
+		return "", "", fmt.Errorf("size rounds down to zero at %d decimals", roundConfig.Size)
+	}
+
+	// The side that pays USDC gets price * size
+	rawAmount := roundOrderAmount(new(big.Rat).Mul(rawSize, rawPrice), roundConfig.Amount)
 
 	if side == SideBuy {
 		// BUY: maker gives USDC (price * size), receives tokens (size)
-		rawMakerAmount = roundAmount(price*size, roundConfig.Amount)
-		rawTakerAmount = roundAmount(size, roundConfig.Size)
+		return toTokenUnits(rawAmount), toTokenUnits(rawSize), nil
+	}
+
+	// SELL: maker gives tokens (size), receives USDC (price * size)
+	return toTokenUnits(rawSize), toTokenUnits(rawAmount), nil
+}
+
+// calculateMarketOrderAmounts calculates maker and taker amounts for a market
+// order. For BUY the amount is the USDC to spend, for SELL the shares to sell;
+// both are rounded down, and the price is rounded to the nearest tick.
+func calculateMarketOrderAmounts(
+	price float64,
+	amount float64,
+	side Side,
+	roundConfig RoundConfig,
+) (string, string, error) {
+	// Validate price
+	if price <= 0 || price > 1 {
+		return "", "", fmt.Errorf("price must be between 0 and 1")
+	}
+
+	rawPrice := roundNormal(decimalFromFloat(price), roundConfig.Price)
+	if rawPrice.Sign() <= 0 {
+		return "", "", fmt.Errorf("price rounds to zero at %d decimals", roundConfig.Price)
+	}
+
+	rawMakerAmount := roundDown(decimalFromFloat(amount), roundConfig.Size)
+	if rawMakerAmount.Sign() <= 0 {
+		return "", "", fmt.Errorf("amount rounds down to zero at %d decimals", roundConfig.Size)
+	}
+
+	var rawTakerAmount *big.Rat
+	if side == SideBuy {
+		// BUY: maker gives USDC (amount), receives tokens (amount / price)
+		rawTakerAmount = new(big.Rat).Quo(rawMakerAmount, rawPrice)
 	} else {
-		// SELL: maker gives tokens (size), receives USDC (price * size)
-		rawMakerAmount = roundAmount(size, roundConfig.Size)
-		rawTakerAmount = roundAmount(price*size, roundConfig.Amount)
+		// SELL: maker gives tokens (amount), receives USDC (amount * price)
+		rawTakerAmount = new(big.Rat).Mul(rawMakerAmount, rawPrice)
 	}
+	rawTakerAmount = roundOrderAmount(rawTakerAmount, roundConfig.Amount)
 
-	// Convert to wei (6 decimals for USDC and tokens)
-	makerAmountWei := new(big.Int)
-	takerAmountWei := new(big.Int)
+	return toTokenUnits(rawMakerAmount), toTokenUnits(rawTakerAmount), nil
+}
 
-	makerAmountWei.SetString(fmt.Sprintf("%.0f", rawMakerAmount*1e6), 10)
-	takerAmountWei.SetString(fmt.Sprintf("%.0f", rawTakerAmount*1e6), 10)
+// calculateMarketPrice walks the side of the book a market order takes from
+// and returns the price of the level at which amount is filled: USDC notional
+// for BUY (against asks), shares for SELL (against bids). If the book is too
+// thin, FOK orders fail and other order types get the worst available price.
+func calculateMarketPrice(
+	book *OrderBookSummary,
+	side Side,
+	amount float64,
+	orderType OrderType,
+) (float64, error) {
+	unit := AmountNotional
+	if side == SideSell {
+		unit = AmountShares
+	}
 
-	return makerAmountWei.String(), takerAmountWei.String(), nil
+	target := decimalFromFloat(amount)
+	f, err := book.simulateFill(side, target, unit)
+	if errors.Is(err, ErrEmptyBook) {
+		return 0, fmt.Errorf("%w: no liquidity to %s", ErrInsufficientLiquidity, side)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if f.leftover.Sign() > 0 && orderType == OrderTypeFOK {
+		filled := new(big.Rat).Sub(target, f.leftover)
+		return 0, fmt.Errorf("%w: book can fill %s of %s", ErrInsufficientLiquidity, filled.FloatString(2), target.FloatString(2))
+	}
+
+	price, _ := f.worst.Float64()
+	return price, nil
 }
 
-// roundAmount rounds an amount to the specified number of decimal places
-func roundAmount(amount float64, decimals int) float64 {
-	multiplier := math.Pow(10, float64(decimals))
-	return math.Round(amount*multiplier) / multiplier
+// roundOrderAmount limits an amount to the given number of decimals the way
+// the reference client does: round up at a few extra decimals first, to absorb
+// noise, then round down if that still isn't enough
+func roundOrderAmount(amount *big.Rat, decimals int) *big.Rat {
+	if decimalPlaces(amount) <= decimals {
+		return amount
+	}
+
+	amount = roundUp(amount, decimals+4)
+	if decimalPlaces(amount) > decimals {
+		amount = roundDown(amount, decimals)
+	}
+
+	return amount
 }
 
 // generateSalt generates a random salt for the order
@@ -0,0 +1,191 @@
+package clobclient
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateOrderAmountsExact(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    float64
+		size     float64
+		side     Side
+		tickSize TickSize
+		maker    string
+		taker    string
+	}{
+		{"BUY 0.57 x 19.3", 0.57, 19.3, SideBuy, TickSize001, "11001000", "19300000"},
+		{"SELL 0.57 x 19.3", 0.57, 19.3, SideSell, TickSize001, "19300000", "11001000"},
+		{"BUY 0.523 x 7.77", 0.523, 7.77, SideBuy, TickSize0001, "4063710", "7770000"},
+		{"BUY 0.0001 tick", 0.0567, 123.45, SideBuy, TickSize00001, "6999615", "123450000"},
+		{"SELL 0.1 tick", 0.3, 33.33, SideSell, TickSize01, "33330000", "9999000"},
+		{"size rounds down", 0.5, 10.019, SideBuy, TickSize001, "5005000", "10010000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maker, taker, err := calculateOrderAmounts(tt.price, tt.size, tt.side, getRoundConfig(tt.tickSize))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.maker, maker)
+			assert.Equal(t, tt.taker, taker)
+		})
+	}
+}
+
+func TestCalculateOrderAmountsRejectsDustSize(t *testing.T) {
+	_, _, err := calculateOrderAmounts(0.5, 0.004, SideBuy, getRoundConfig(TickSize001))
+	assert.Error(t, err)
+}
+
+// Property: for any tick-aligned price and any size, the price the exchange
+// derives from the amounts (USDC / tokens) is exactly the order price.
+func TestCalculateOrderAmountsReproducesPrice(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	tickSizes := []TickSize{TickSize01, TickSize001, TickSize0001, TickSize00001}
+
+	for i := 0; i < 5000; i++ {
+		tickSize := tickSizes[rng.Intn(len(tickSizes))]
+		roundConfig := getRoundConfig(tickSize)
+
+		ticks := int64(1)
+		for j := 0; j < roundConfig.Price; j++ {
+			ticks *= 10
+		}
+		priceTicks := 1 + rng.Int63n(ticks-1)
+		price := decimalFromFloat(float64(priceTicks) / float64(ticks))
+		priceFloat, _ := price.Float64()
+
+		size := float64(1+rng.Intn(1000000)) / 10000
+		side := SideBuy
+		if rng.Intn(2) == 1 {
+			side = SideSell
+		}
+
+		name := fmt.Sprintf("%s %s x %v (tick %s)", side, price.FloatString(roundConfig.Price), size, tickSize)
+
+		maker, taker, err := calculateOrderAmounts(priceFloat, size, side, roundConfig)
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+
+		makerAmount, _ := new(big.Int).SetString(maker, 10)
+		takerAmount, _ := new(big.Int).SetString(taker, 10)
+
+		usdc, tokens := makerAmount, takerAmount
+		if side == SideSell {
+			usdc, tokens = takerAmount, makerAmount
+		}
+
+		derived := new(big.Rat).SetFrac(usdc, tokens)
+		assert.Equal(t, 0, derived.Cmp(price), "%s: derived price %s", name, derived.FloatString(8))
+
+		// Sizes are rounded down to the size precision, never up
+		expectedTokens := roundDown(decimalFromFloat(size), roundConfig.Size)
+		assert.Equal(t, toTokenUnits(expectedTokens), tokens.String(), name)
+	}
+}
+
+func testBook() *OrderBookSummary {
+	// Levels in the order the server returns them: best bid and best ask last
+	return &OrderBookSummary{
+		Market:  "0xmarket",
+		AssetID: "123",
+		Bids: []OrderSummary{
+			{Price: "0.48", Size: "100"},
+			{Price: "0.49", Size: "50"},
+			{Price: "0.50", Size: "20"},
+		},
+		Asks: []OrderSummary{
+			{Price: "0.55", Size: "100"},
+			{Price: "0.53", Size: "50"},
+			{Price: "0.52", Size: "20"},
+		},
+		TickSize: "0.01",
+	}
+}
+
+func TestCalculateMarketPrice(t *testing.T) {
+	book := testBook()
+
+	tests := []struct {
+		name      string
+		side      Side
+		amount    float64
+		orderType OrderType
+		expected  float64
+		expectErr bool
+	}{
+		// 20 * 0.52 = 10.4 USDC at the best ask
+		{"BUY within best ask", SideBuy, 10, OrderTypeFOK, 0.52, false},
+		{"BUY exactly best ask", SideBuy, 10.4, OrderTypeFOK, 0.52, false},
+		// 10.4 + 50 * 0.53 = 36.9 USDC
+		{"BUY second level", SideBuy, 30, OrderTypeFOK, 0.53, false},
+		{"BUY deeper than book FOK", SideBuy, 1000, OrderTypeFOK, 0, true},
+		{"BUY deeper than book FAK", SideBuy, 1000, OrderTypeFAK, 0.55, false},
+		{"SELL within best bid", SideSell, 20, OrderTypeFOK, 0.50, false},
+		{"SELL third level", SideSell, 100, OrderTypeFOK, 0.48, false},
+		{"SELL deeper than book FOK", SideSell, 171, OrderTypeFOK, 0, true},
+		{"SELL deeper than book FAK", SideSell, 171, OrderTypeFAK, 0.48, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := calculateMarketPrice(book, tt.side, tt.amount, tt.orderType)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, price)
+			}
+		})
+	}
+
+	_, err := calculateMarketPrice(&OrderBookSummary{}, SideBuy, 10, OrderTypeFAK)
+	assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+}
+
+func TestCalculateMarketOrderAmounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    float64
+		amount   float64
+		side     Side
+		tickSize TickSize
+		maker    string
+		taker    string
+	}{
+		// 100 USDC / 0.53 = 188.679245... shares, truncated to 4 decimals
+		{"BUY 100 USDC at 0.53", 0.53, 100, SideBuy, TickSize001, "100000000", "188679200"},
+		{"BUY 10.4 USDC at 0.52", 0.52, 10.4, SideBuy, TickSize001, "10400000", "20000000"},
+		{"SELL 19.3 shares at 0.57", 0.57, 19.3, SideSell, TickSize001, "19300000", "11001000"},
+		{"amount rounded down", 0.5, 10.019, SideSell, TickSize001, "10010000", "5005000"},
+		// Prices are rounded to the nearest tick, not truncated below it
+		{"price just below a tick", 0.5299999, 100, SideBuy, TickSize001, "100000000", "188679200"},
+		{"price just above a tick", 0.5300001, 100, SideBuy, TickSize001, "100000000", "188679200"},
+		{"SELL price half a tick up", 0.565, 19.3, SideSell, TickSize001, "19300000", "11001000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maker, taker, err := calculateMarketOrderAmounts(tt.price, tt.amount, tt.side, getRoundConfig(tt.tickSize))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.maker, maker)
+			assert.Equal(t, tt.taker, taker)
+		})
+	}
+}
+
+func TestBuildMarketOrderRequiresPrice(t *testing.T) {
+	builder := NewOrderBuilder(testPrivateKey, int(ChainPolygon), SignatureTypeEOA, nil)
+
+	_, err := builder.BuildMarketOrder(
+		&UserMarketOrder{TokenID: "123", Amount: 10, Side: SideBuy},
+		&CreateOrderOptions{TickSize: TickSize001},
+	)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package clobclient
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FillState tells how much of an order matched when it was placed
+type FillState string
+
+const (
+	FillStateNone    FillState = "NONE"    // Nothing matched
+	FillStatePartial FillState = "PARTIAL" // Part of the order matched
+	FillStateFull    FillState = "FULL"    // The whole order matched
+	FillStatePending FillState = "PENDING" // Matching is delayed, the fill is not known yet
+)
+
+// OrderFill is the part of an order that matched on placement
+type OrderFill struct {
+	// Shares and Notional are the matched size in shares and USDC
+	Shares   float64
+	Notional float64
+	// AveragePrice is Notional / Shares, or 0 if nothing matched
+	AveragePrice float64
+	State        FillState
+}
+
+// TakingAmountValue returns what the order received when it matched: USDC
+// for a SELL, shares for a BUY. It is 0 if nothing matched.
+func (r *OrderResponse) TakingAmountValue() (float64, error) {
+	amount, err := orderAmount(r.TakingAmount)
+	if err != nil {
+		return 0, err
+	}
+	value, _ := amount.Float64()
+	return value, nil
+}
+
+// MakingAmountValue returns what the order gave up when it matched: shares
+// for a SELL, USDC for a BUY. It is 0 if nothing matched.
+func (r *OrderResponse) MakingAmountValue() (float64, error) {
+	amount, err := orderAmount(r.MakingAmount)
+	if err != nil {
+		return 0, err
+	}
+	value, _ := amount.Float64()
+	return value, nil
+}
+
+// Fill computes how much of an order of the given side matched on placement.
+// amount is the size that was requested, counted in unit: shares for limit
+// orders and market SELL orders, USDC for market BUY orders. This tells
+// whether a FAK or FOK order was fully, partially or not filled.
+func (r *OrderResponse) Fill(side Side, amount float64, unit AmountUnit) (*OrderFill, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	taking, err := orderAmount(r.TakingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid taking amount: %w", err)
+	}
+	making, err := orderAmount(r.MakingAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid making amount: %w", err)
+	}
+
+	shares, notional := taking, making
+	if side == SideSell {
+		shares, notional = making, taking
+	}
+
+	filled := shares
+	if unit == AmountNotional {
+		filled = notional
+	}
+
+	fill := &OrderFill{}
+	switch {
+	case r.Status == OrderStatusDelayed:
+		fill.State = FillStatePending
+	case filled.Sign() == 0:
+		fill.State = FillStateNone
+	case filled.Cmp(decimalFromFloat(amount)) >= 0:
+		fill.State = FillStateFull
+	default:
+		fill.State = FillStatePartial
+	}
+
+	fill.Shares, _ = shares.Float64()
+	fill.Notional, _ = notional.Float64()
+	if shares.Sign() > 0 {
+		fill.AveragePrice, _ = new(big.Rat).Quo(notional, shares).Float64()
+	}
+
+	return fill, nil
+}
+
+// orderAmount parses an amount of an order response. The exchange leaves
+// amounts empty when nothing matched.
+func orderAmount(amount string) (*big.Rat, error) {
+	if amount == "" {
+		return new(big.Rat), nil
+	}
+	return decimalFromString(amount)
+}
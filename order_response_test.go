@@ -0,0 +1,95 @@
+package clobclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderResponseDecodesStatus(t *testing.T) {
+	var resp OrderResponse
+	err := json.Unmarshal([]byte(`{"success":true,"orderID":"0x1","status":"matched","takingAmount":"20","makingAmount":"10.4"}`), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, OrderStatusMatched, resp.Status)
+
+	taking, err := resp.TakingAmountValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, taking)
+
+	making, err := resp.MakingAmountValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 10.4, making)
+
+	// Unmatched orders come back without amounts
+	empty := OrderResponse{Status: OrderStatusLive}
+	taking, err = empty.TakingAmountValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, taking)
+}
+
+func TestOrderResponseFill(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     OrderResponse
+		side     Side
+		amount   float64
+		unit     AmountUnit
+		expected OrderFill
+	}{
+		{
+			name:     "buy fully filled",
+			resp:     OrderResponse{Status: OrderStatusMatched, TakingAmount: "20", MakingAmount: "10.4"},
+			side:     SideBuy,
+			amount:   20,
+			unit:     AmountShares,
+			expected: OrderFill{Shares: 20, Notional: 10.4, AveragePrice: 0.52, State: FillStateFull},
+		},
+		{
+			name:     "market buy partially filled",
+			resp:     OrderResponse{Status: OrderStatusMatched, TakingAmount: "10", MakingAmount: "5"},
+			side:     SideBuy,
+			amount:   8,
+			unit:     AmountNotional,
+			expected: OrderFill{Shares: 10, Notional: 5, AveragePrice: 0.5, State: FillStatePartial},
+		},
+		{
+			name:     "sell partially filled",
+			resp:     OrderResponse{Status: OrderStatusLive, TakingAmount: "2.4", MakingAmount: "6"},
+			side:     SideSell,
+			amount:   10,
+			unit:     AmountShares,
+			expected: OrderFill{Shares: 6, Notional: 2.4, AveragePrice: 0.4, State: FillStatePartial},
+		},
+		{
+			name:     "not filled",
+			resp:     OrderResponse{Status: OrderStatusLive},
+			side:     SideBuy,
+			amount:   10,
+			unit:     AmountShares,
+			expected: OrderFill{State: FillStateNone},
+		},
+		{
+			name:     "delayed",
+			resp:     OrderResponse{Status: OrderStatusDelayed},
+			side:     SideBuy,
+			amount:   10,
+			unit:     AmountShares,
+			expected: OrderFill{State: FillStatePending},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fill, err := tt.resp.Fill(tt.side, tt.amount, tt.unit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *fill)
+		})
+	}
+
+	resp := OrderResponse{TakingAmount: "abc"}
+	_, err := resp.Fill(SideBuy, 1, AmountShares)
+	assert.Error(t, err)
+	_, err = resp.Fill(SideBuy, 0, AmountShares)
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Cursors used by the paginated endpoints
+const (
+	// InitialCursor requests the first page
+	InitialCursor = "MA=="
+	// EndCursor is returned as next_cursor once the last page has been served
+	EndCursor = "LTE="
+)
+
+// Page is a single page of a paginated response
+type Page[T any] struct {
+	Limit      int    `json:"limit"`
+	Count      int    `json:"count"`
+	NextCursor string `json:"next_cursor"`
+	Data       []T    `json:"data"`
+}
+
+// IsLast reports whether no further page follows this one
+func (p *Page[T]) IsLast() bool {
+	return p.NextCursor == "" || p.NextCursor == EndCursor
+}
+
+// PageIterator lazily walks a paginated endpoint, fetching the next page only
+// once the items of the current one have been consumed:
+//
+//	it := client.TradesIterator(params)
+//	for it.Next(ctx) {
+//		trade := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+//
+// A PageIterator is not safe for concurrent use.
+type PageIterator[T any] struct {
+	fetch  func(ctx context.Context, cursor string) (*Page[T], error)
+	cursor string
+	seen   map[string]bool
+	items  []T
+	index  int
+	value  T
+	err    error
+	done   bool
+}
+
+// newPageIterator returns an iterator starting at the first page
+func newPageIterator[T any](fetch func(ctx context.Context, cursor string) (*Page[T], error)) *PageIterator[T] {
+	return &PageIterator[T]{fetch: fetch, cursor: InitialCursor, seen: make(map[string]bool)}
+}
+
+// Next advances to the next item, fetching a new page if needed. It returns
+// false once all pages are consumed, ctx is done, a request fails or the
+// server repeats a cursor (ErrCursorLoop); Err distinguishes the cases.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if it.done {
+			return false
+		}
+
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		page, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.seen[it.cursor] = true
+
+		if !page.IsLast() && it.seen[page.NextCursor] {
+			it.err = fmt.Errorf("%w: %s", ErrCursorLoop, page.NextCursor)
+			return false
+		}
+
+		it.items = page.Data
+		it.index = 0
+		it.cursor = page.NextCursor
+		it.done = page.IsLast()
+	}
+
+	it.value = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current item
+func (it *PageIterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// collect drains the iterator
+func (it *PageIterator[T]) collect(ctx context.Context) ([]T, error) {
+	items := []T{}
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// getPage fetches one page of an authenticated paginated endpoint
+func getPage[T any](
+	ctx context.Context,
+	c *ClobClient,
+	endpoint string,
+	params interface{},
+	cursor string,
+) (*Page[T], error) {
+	creds := c.Credentials()
+	if creds == nil {
+		return nil, fmt.Errorf("API credentials required")
+	}
+
+	requestPath := endpoint + "?" + withCursor(buildQueryParams(params), cursor)
+
+	headers, err := CreateL2Headers(
+		c.PrivateKey,
+		creds,
+		http.MethodGet,
+		requestPath,
+		"",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
+	}
+
+	resp, err := c.HTTPClient.GetWithContext(ctx, c.Host+requestPath, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePage[T](resp)
+}
+
+// getPublicPage fetches one page of a public paginated endpoint
+func getPublicPage[T any](ctx context.Context, c *ClobClient, endpoint string, cursor string) (*Page[T], error) {
+	resp, err := c.HTTPClient.GetWithContext(ctx, c.Host+endpoint+"?"+withCursor("", cursor), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePage[T](resp)
+}
+
+// parsePage decodes a paginated response
+func parsePage[T any](resp []byte) (*Page[T], error) {
+	var page Page[T]
+	if err := json.Unmarshal(resp, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+	return &page, nil
+}
+
+// withCursor appends the next_cursor parameter to a query string
+func withCursor(query string, cursor string) string {
+	if cursor == "" {
+		cursor = InitialCursor
+	}
+
+	param := "next_cursor=" + url.QueryEscape(cursor)
+	if query == "" {
+		return param
+	}
+	return query + "&" + param
+}
+
+// GetOpenOrdersPage retrieves one page of open orders. Pass InitialCursor (or
+// "") for the first page and the returned NextCursor for the following ones.
+func (c *ClobClient) GetOpenOrdersPage(params *OpenOrderParams, cursor string) (*Page[OpenOrder], error) {
+	return c.GetOpenOrdersPageWithContext(context.Background(), params, cursor)
+}
+
+// GetOpenOrdersPageWithContext is like GetOpenOrdersPage but bound to ctx
+func (c *ClobClient) GetOpenOrdersPageWithContext(
+	ctx context.Context,
+	params *OpenOrderParams,
+	cursor string,
+) (*Page[OpenOrder], error) {
+	page, err := getPage[OpenOrder](ctx, c, EndpointGetOpenOrders, params, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open orders: %w", err)
+	}
+	return page, nil
+}
+
+// OpenOrdersIterator returns an iterator over all open orders matching params
+func (c *ClobClient) OpenOrdersIterator(params *OpenOrderParams) *PageIterator[OpenOrder] {
+	return newPageIterator(func(ctx context.Context, cursor string) (*Page[OpenOrder], error) {
+		return c.GetOpenOrdersPageWithContext(ctx, params, cursor)
+	})
+}
+
+// GetAllOpenOrders retrieves every page of open orders matching params
+func (c *ClobClient) GetAllOpenOrders(params *OpenOrderParams) ([]OpenOrder, error) {
+	return c.GetAllOpenOrdersWithContext(context.Background(), params)
+}
+
+// GetAllOpenOrdersWithContext is like GetAllOpenOrders but bound to ctx
+func (c *ClobClient) GetAllOpenOrdersWithContext(ctx context.Context, params *OpenOrderParams) ([]OpenOrder, error) {
+	return c.OpenOrdersIterator(params).collect(ctx)
+}
+
+// GetTradesPage retrieves one page of trades. Pass InitialCursor (or "") for
+// the first page and the returned NextCursor for the following ones.
+func (c *ClobClient) GetTradesPage(params *TradeParams, cursor string) (*Page[Trade], error) {
+	return c.GetTradesPageWithContext(context.Background(), params, cursor)
+}
+
+// GetTradesPageWithContext is like GetTradesPage but bound to ctx
+func (c *ClobClient) GetTradesPageWithContext(
+	ctx context.Context,
+	params *TradeParams,
+	cursor string,
+) (*Page[Trade], error) {
+	page, err := getPage[Trade](ctx, c, EndpointGetTrades, params, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	return page, nil
+}
+
+// TradesIterator returns an iterator over all trades matching params
+func (c *ClobClient) TradesIterator(params *TradeParams) *PageIterator[Trade] {
+	return newPageIterator(func(ctx context.Context, cursor string) (*Page[Trade], error) {
+		return c.GetTradesPageWithContext(ctx, params, cursor)
+	})
+}
+
+// GetAllTrades retrieves every page of trades matching params
+func (c *ClobClient) GetAllTrades(params *TradeParams) ([]Trade, error) {
+	return c.GetAllTradesWithContext(context.Background(), params)
+}
+
+// GetAllTradesWithContext is like GetAllTrades but bound to ctx
+func (c *ClobClient) GetAllTradesWithContext(ctx context.Context, params *TradeParams) ([]Trade, error) {
+	return c.TradesIterator(params).collect(ctx)
+}
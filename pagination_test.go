@@ -0,0 +1,164 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTradesServer serves pages of two trades each, ending after pages pages
+func newTradesServer(t *testing.T, pages int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		assert.Equal(t, EndpointGetTrades, r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("POLY_SIGNATURE"))
+
+		cursor := r.URL.Query().Get("next_cursor")
+		page := 0
+		if cursor != InitialCursor {
+			fmt.Sscanf(cursor, "page-%d", &page)
+		}
+
+		next := fmt.Sprintf("page-%d", page+1)
+		if page+1 == pages {
+			next = EndCursor
+		}
+
+		json.NewEncoder(w).Encode(Page[Trade]{
+			Limit:      2,
+			Count:      2,
+			NextCursor: next,
+			Data: []Trade{
+				{ID: fmt.Sprintf("trade-%d", 2*page)},
+				{ID: fmt.Sprintf("trade-%d", 2*page+1)},
+			},
+		})
+	}))
+}
+
+func TestGetAllTradesFollowsCursor(t *testing.T) {
+	var requests int32
+	server := newTradesServer(t, 3, &requests)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	trades, err := client.GetAllTrades(&TradeParams{})
+	assert.NoError(t, err)
+	assert.Len(t, trades, 6)
+	for i, trade := range trades {
+		assert.Equal(t, fmt.Sprintf("trade-%d", i), trade.ID)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// The deprecated single page call follows the cursor too
+	trades, err = client.GetTrades(&TradeParams{})
+	assert.NoError(t, err)
+	assert.Len(t, trades, 6)
+}
+
+func TestPageIteratorStopsEarly(t *testing.T) {
+	var requests int32
+	server := newTradesServer(t, 10, &requests)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	it := client.TradesIterator(nil)
+	for i := 0; i < 3; i++ {
+		assert.True(t, it.Next(context.Background()))
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, "trade-2", it.Value().ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestPageIteratorStopsOnCancelledContext(t *testing.T) {
+	var requests int32
+	server := newTradesServer(t, 10, &requests)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.TradesIterator(nil)
+	assert.True(t, it.Next(ctx))
+	assert.True(t, it.Next(ctx))
+
+	// The current page is exhausted, so the next call must not fetch another one
+	cancel()
+	assert.False(t, it.Next(ctx))
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestPageIteratorStopsOnRepeatedCursor(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages map[string]Page[Trade]
+		items int
+	}{
+		{
+			name: "same cursor",
+			pages: map[string]Page[Trade]{
+				InitialCursor: {NextCursor: "a", Data: []Trade{{ID: "1"}}},
+				"a":           {NextCursor: "a", Data: []Trade{{ID: "2"}}},
+			},
+			items: 1,
+		},
+		{
+			name: "empty pages in a cycle",
+			pages: map[string]Page[Trade]{
+				InitialCursor: {NextCursor: "a"},
+				"a":           {NextCursor: "b"},
+				"b":           {NextCursor: "a"},
+			},
+			items: 0,
+		},
+		{
+			name: "back to the first page",
+			pages: map[string]Page[Trade]{
+				InitialCursor: {NextCursor: InitialCursor, Data: []Trade{{ID: "1"}}},
+			},
+			items: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := newPageIterator(func(ctx context.Context, cursor string) (*Page[Trade], error) {
+				page := tt.pages[cursor]
+				return &page, nil
+			})
+
+			items, err := it.collect(context.Background())
+			assert.ErrorIs(t, err, ErrCursorLoop)
+			assert.Len(t, items, tt.items)
+		})
+	}
+}
+
+func TestGetOpenOrdersPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetOpenOrders, r.URL.Path)
+		assert.Equal(t, "0xmarket", r.URL.Query().Get("market"))
+		assert.Equal(t, "abc=", r.URL.Query().Get("next_cursor"))
+		w.Write([]byte(`{"limit":100,"count":1,"next_cursor":"LTE=","data":[{"id":"0xa","status":"LIVE"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	market := "0xmarket"
+
+	page, err := client.GetOpenOrdersPage(&OpenOrderParams{Market: &market}, "abc=")
+	assert.NoError(t, err)
+	assert.True(t, page.IsLast())
+	assert.Equal(t, []OpenOrder{{ID: "0xa", Status: OrderStatusLive}}, page.Data)
+}
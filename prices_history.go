@@ -0,0 +1,203 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultPriceHistoryWindow is the span fetched per request by GetPricesHistoryRange
+const DefaultPriceHistoryWindow = 7 * 24 * time.Hour
+
+// PriceHistoryRangeParams represents parameters for GetPricesHistoryRange
+type PriceHistoryRangeParams struct {
+	// Market is the token ID
+	Market string
+	// StartTs and EndTs bound the range, in unix seconds
+	StartTs int64
+	EndTs   int64
+	// Window is the span fetched per request, DefaultPriceHistoryWindow if zero
+	Window time.Duration
+	// Fidelity is the resolution of the data in minutes
+	Fidelity *int
+}
+
+// Candle represents an OHLC candle built from price history points
+type Candle struct {
+	// Timestamp is the start of the candle in unix seconds
+	Timestamp int64   `json:"t"`
+	Open      float64 `json:"o"`
+	High      float64 `json:"h"`
+	Low       float64 `json:"l"`
+	Close     float64 `json:"c"`
+	// Points is the number of price points aggregated into the candle
+	Points int `json:"n"`
+}
+
+// GetPricesHistory retrieves the price history of a token
+func (c *ClobClient) GetPricesHistory(params *PriceHistoryFilterParams) ([]MarketPrice, error) {
+	return c.GetPricesHistoryWithContext(context.Background(), params)
+}
+
+// GetPricesHistoryWithContext is like GetPricesHistory but bound to ctx
+func (c *ClobClient) GetPricesHistoryWithContext(
+	ctx context.Context,
+	params *PriceHistoryFilterParams,
+) ([]MarketPrice, error) {
+	endpoint := c.Host + EndpointGetPricesHistory
+	if query := priceHistoryQuery(params); query != "" {
+		endpoint += "?" + query
+	}
+
+	resp, err := c.HTTPClient.GetWithContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices history: %w", err)
+	}
+
+	var result struct {
+		History []MarketPrice `json:"history"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse prices history: %w", err)
+	}
+
+	return result.History, nil
+}
+
+// priceHistoryQuery encodes the price history parameters. Timestamps are
+// written as integers, which buildQueryParams would not preserve.
+func priceHistoryQuery(params *PriceHistoryFilterParams) string {
+	if params == nil {
+		return ""
+	}
+
+	query := url.Values{}
+	if params.Market != nil {
+		query.Set("market", *params.Market)
+	}
+	if params.StartTs != nil {
+		query.Set("startTs", strconv.FormatInt(*params.StartTs, 10))
+	}
+	if params.EndTs != nil {
+		query.Set("endTs", strconv.FormatInt(*params.EndTs, 10))
+	}
+	if params.Fidelity != nil {
+		query.Set("fidelity", strconv.Itoa(*params.Fidelity))
+	}
+	if params.Interval != nil {
+		query.Set("interval", string(*params.Interval))
+	}
+
+	return query.Encode()
+}
+
+// GetPricesHistoryRange retrieves the price history between StartTs and EndTs,
+// splitting the range into windows the server accepts and stitching the
+// results together. Points are sorted by timestamp and deduplicated.
+func (c *ClobClient) GetPricesHistoryRange(params *PriceHistoryRangeParams) ([]MarketPrice, error) {
+	return c.GetPricesHistoryRangeWithContext(context.Background(), params)
+}
+
+// GetPricesHistoryRangeWithContext is like GetPricesHistoryRange but bound to ctx
+func (c *ClobClient) GetPricesHistoryRangeWithContext(
+	ctx context.Context,
+	params *PriceHistoryRangeParams,
+) ([]MarketPrice, error) {
+	if params.EndTs <= params.StartTs {
+		return nil, fmt.Errorf("invalid range: end %d is not after start %d", params.EndTs, params.StartTs)
+	}
+
+	window := int64(params.Window / time.Second)
+	if params.Window == 0 {
+		window = int64(DefaultPriceHistoryWindow / time.Second)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid window %s", params.Window)
+	}
+
+	seen := make(map[int64]bool)
+	var history []MarketPrice
+	for start := params.StartTs; start < params.EndTs; start += window {
+		end := start + window
+		if end > params.EndTs {
+			end = params.EndTs
+		}
+
+		market, windowStart, windowEnd := params.Market, start, end
+		points, err := c.GetPricesHistoryWithContext(ctx, &PriceHistoryFilterParams{
+			Market:   &market,
+			StartTs:  &windowStart,
+			EndTs:    &windowEnd,
+			Fidelity: params.Fidelity,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Adjacent windows share their boundary, so a point may be returned twice
+		for _, point := range points {
+			if !seen[point.Timestamp] {
+				seen[point.Timestamp] = true
+				history = append(history, point)
+			}
+		}
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Timestamp < history[j].Timestamp
+	})
+
+	return history, nil
+}
+
+// BuildCandles resamples price points into OHLC candles of the given interval.
+// Candles are aligned to multiples of the interval since the unix epoch and
+// returned in chronological order; intervals without points produce no candle.
+func BuildCandles(points []MarketPrice, interval time.Duration) ([]Candle, error) {
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return nil, fmt.Errorf("invalid candle interval %s", interval)
+	}
+
+	sorted := make([]MarketPrice, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+
+	var candles []Candle
+	for _, point := range sorted {
+		start := point.Timestamp - point.Timestamp%seconds
+		if point.Timestamp%seconds < 0 {
+			start -= seconds
+		}
+
+		if len(candles) == 0 || candles[len(candles)-1].Timestamp != start {
+			candles = append(candles, Candle{
+				Timestamp: start,
+				Open:      point.Price,
+				High:      point.Price,
+				Low:       point.Price,
+				Close:     point.Price,
+				Points:    1,
+			})
+			continue
+		}
+
+		candle := &candles[len(candles)-1]
+		if point.Price > candle.High {
+			candle.High = point.Price
+		}
+		if point.Price < candle.Low {
+			candle.Low = point.Price
+		}
+		candle.Close = point.Price
+		candle.Points++
+	}
+
+	return candles, nil
+}
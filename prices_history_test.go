@@ -0,0 +1,95 @@
+package clobclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPricesHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetPricesHistory, r.URL.Path)
+		assert.Equal(t, "123", r.URL.Query().Get("market"))
+		assert.Equal(t, "1700000000", r.URL.Query().Get("startTs"))
+		assert.Equal(t, "60", r.URL.Query().Get("fidelity"))
+		w.Write([]byte(`{"history":[{"t":1700000000,"p":0.5},{"t":1700003600,"p":0.52}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	market := "123"
+	start := int64(1700000000)
+	fidelity := 60
+
+	history, err := client.GetPricesHistory(&PriceHistoryFilterParams{
+		Market:   &market,
+		StartTs:  &start,
+		Fidelity: &fidelity,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []MarketPrice{{1700000000, 0.5}, {1700003600, 0.52}}, history)
+}
+
+func TestGetPricesHistoryRangeStitchesWindows(t *testing.T) {
+	var windows [][2]int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.ParseInt(r.URL.Query().Get("startTs"), 10, 64)
+		end, _ := strconv.ParseInt(r.URL.Query().Get("endTs"), 10, 64)
+		windows = append(windows, [2]int64{start, end})
+
+		// One point at each boundary of the window, newest first
+		fmt.Fprintf(w, `{"history":[{"t":%d,"p":0.6},{"t":%d,"p":0.4}]}`, end, start)
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+
+	history, err := client.GetPricesHistoryRange(&PriceHistoryRangeParams{
+		Market:  "123",
+		StartTs: 0,
+		EndTs:   250,
+		Window:  100 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int64{{0, 100}, {100, 200}, {200, 250}}, windows)
+
+	var timestamps []int64
+	for _, point := range history {
+		timestamps = append(timestamps, point.Timestamp)
+	}
+	assert.Equal(t, []int64{0, 100, 200, 250}, timestamps)
+
+	_, err = client.GetPricesHistoryRange(&PriceHistoryRangeParams{Market: "123", StartTs: 10, EndTs: 10})
+	assert.Error(t, err)
+}
+
+func TestBuildCandles(t *testing.T) {
+	points := []MarketPrice{
+		{Timestamp: 125, Price: 0.55},
+		{Timestamp: 60, Price: 0.50},
+		{Timestamp: 90, Price: 0.58},
+		{Timestamp: 100, Price: 0.47},
+		{Timestamp: 119, Price: 0.52},
+		{Timestamp: 300, Price: 0.60},
+	}
+
+	candles, err := BuildCandles(points, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{
+		{Timestamp: 60, Open: 0.50, High: 0.58, Low: 0.47, Close: 0.52, Points: 4},
+		{Timestamp: 120, Open: 0.55, High: 0.55, Low: 0.55, Close: 0.55, Points: 1},
+		{Timestamp: 300, Open: 0.60, High: 0.60, Low: 0.60, Close: 0.60, Points: 1},
+	}, candles)
+
+	candles, err = BuildCandles(nil, time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, candles)
+
+	_, err = BuildCandles(points, time.Millisecond)
+	assert.Error(t, err)
+}
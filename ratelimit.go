@@ -0,0 +1,257 @@
+package clobclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request cannot obtain a rate limit token
+// before its context deadline
+var ErrRateLimited = errors.New("rate limit would be exceeded before context deadline")
+
+// EndpointGroup identifies a client-side rate limit bucket
+type EndpointGroup string
+
+const (
+	EndpointGroupOrders  EndpointGroup = "orders"
+	EndpointGroupCancels EndpointGroup = "cancels"
+	EndpointGroupBooks   EndpointGroup = "books"
+	EndpointGroupAuth    EndpointGroup = "auth"
+	EndpointGroupData    EndpointGroup = "data"
+)
+
+// RateLimit configures a token bucket: Rate tokens are added per second, up to
+// Burst. A bucket with a Rate of zero or less never refills.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// DefaultRateLimits returns conservative per-group limits derived from the
+// published CLOB limits (expressed there per 10 seconds). Adjust them to
+// match your account tier.
+func DefaultRateLimits() map[EndpointGroup]RateLimit {
+	return map[EndpointGroup]RateLimit{
+		EndpointGroupOrders:  {Rate: 40, Burst: 240},
+		EndpointGroupCancels: {Rate: 40, Burst: 240},
+		EndpointGroupBooks:   {Rate: 20, Burst: 50},
+		EndpointGroupAuth:    {Rate: 10, Burst: 10},
+		EndpointGroupData:    {Rate: 15, Burst: 50},
+	}
+}
+
+// BucketState is a point-in-time view of a rate limit bucket
+type BucketState struct {
+	Group     EndpointGroup `json:"group"`
+	Rate      float64       `json:"rate"`
+	Burst     int           `json:"burst"`
+	Tokens    float64       `json:"tokens"`
+	Waiting   int           `json:"waiting"`
+	Throttled uint64        `json:"throttled"`
+}
+
+// RateLimiter paces requests with one token bucket per endpoint group.
+// Groups without a configured limit are not paced. It is safe for concurrent use.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[EndpointGroup]*tokenBucket
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	limit     RateLimit
+	tokens    float64
+	last      time.Time
+	waiting   int
+	throttled uint64
+}
+
+// NewRateLimiter creates a rate limiter with the given per-group limits
+func NewRateLimiter(limits map[EndpointGroup]RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		buckets: make(map[EndpointGroup]*tokenBucket),
+		now:     time.Now,
+	}
+
+	for group, limit := range limits {
+		l.SetLimit(group, limit)
+	}
+
+	return l
+}
+
+// SetLimit sets or replaces the limit of a group; the bucket starts full
+func (l *RateLimiter) SetLimit(group EndpointGroup, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	l.buckets[group] = &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   l.now(),
+	}
+}
+
+// Allow takes a token from the group's bucket without waiting and reports
+// whether one was available
+func (l *RateLimiter) Allow(group EndpointGroup) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[group]
+	if !ok {
+		return true
+	}
+
+	bucket.refill(l.now())
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true
+	}
+
+	bucket.throttled++
+	return false
+}
+
+// Wait blocks until a token is available in the group's bucket or ctx is done.
+// If ctx has a deadline that would pass before a token becomes available, or
+// the bucket never refills, Wait fails fast with ErrRateLimited instead of
+// blocking.
+func (l *RateLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	bucket, ok := l.buckets[group]
+	if !ok {
+		l.mu.Unlock()
+		return nil
+	}
+
+	now := l.now()
+	bucket.refill(now)
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+
+	// Waiting can't help a bucket that never refills
+	if bucket.limit.Rate <= 0 {
+		bucket.throttled++
+		l.mu.Unlock()
+		return fmt.Errorf("%w (%s bucket is empty and never refills)", ErrRateLimited, group)
+	}
+
+	delay := bucket.delayFor(1)
+	if deadline, ok := ctx.Deadline(); ok && delay > deadline.Sub(now) {
+		bucket.throttled++
+		l.mu.Unlock()
+		return fmt.Errorf("%w (%s bucket)", ErrRateLimited, group)
+	}
+
+	// Reserve the token now so that concurrent waiters queue up behind us
+	bucket.tokens--
+	bucket.waiting++
+	bucket.throttled++
+	l.mu.Unlock()
+
+	err := sleepWithContext(ctx, delay)
+
+	l.mu.Lock()
+	bucket.waiting--
+	if err != nil {
+		// Hand the reservation back so it is not lost, without exceeding the
+		// burst if the bucket refilled while we slept
+		bucket.refill(l.now())
+		bucket.tokens = math.Min(float64(bucket.limit.Burst), bucket.tokens+1)
+	}
+	l.mu.Unlock()
+
+	return err
+}
+
+// State returns the current state of every bucket, sorted by group
+func (l *RateLimiter) State() []BucketState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	states := make([]BucketState, 0, len(l.buckets))
+	for group, bucket := range l.buckets {
+		bucket.refill(now)
+		states = append(states, BucketState{
+			Group:     group,
+			Rate:      bucket.limit.Rate,
+			Burst:     bucket.limit.Burst,
+			Tokens:    bucket.tokens,
+			Waiting:   bucket.waiting,
+			Throttled: bucket.throttled,
+		})
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Group < states[j].Group
+	})
+
+	return states
+}
+
+// refill adds the tokens accrued since the last refill
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+	b.last = now
+}
+
+// delayFor returns how long until n tokens are available
+func (b *tokenBucket) delayFor(n float64) time.Duration {
+	missing := n - b.tokens
+	if missing <= 0 {
+		return 0
+	}
+	if b.limit.Rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(missing / b.limit.Rate * float64(time.Second))
+}
+
+// classifyEndpoint maps a request to the rate limit group it counts against
+func classifyEndpoint(method string, path string) EndpointGroup {
+	switch {
+	case strings.HasPrefix(path, "/auth/"):
+		return EndpointGroupAuth
+	case path == EndpointCancelAll || path == EndpointCancelMarketOrders || path == EndpointCancelOrders:
+		return EndpointGroupCancels
+	case path == EndpointPostOrder || path == EndpointPostOrders:
+		if method == http.MethodDelete {
+			return EndpointGroupCancels
+		}
+		return EndpointGroupOrders
+	}
+
+	switch path {
+	case EndpointGetOrderBook, EndpointGetOrderBooks, EndpointGetPrice, EndpointGetPrices,
+		EndpointGetMidpoint, EndpointGetMidpoints, EndpointGetSpreads, EndpointGetTickSize, EndpointGetNegRisk:
+		return EndpointGroupBooks
+	}
+
+	return EndpointGroupData
+}
@@ -0,0 +1,180 @@
+package clobclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterAllowRefills(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := NewRateLimiter(nil)
+	limiter.now = func() time.Time { return now }
+	limiter.SetLimit(EndpointGroupOrders, RateLimit{Rate: 2, Burst: 2})
+
+	assert.True(t, limiter.Allow(EndpointGroupOrders))
+	assert.True(t, limiter.Allow(EndpointGroupOrders))
+	assert.False(t, limiter.Allow(EndpointGroupOrders))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.Allow(EndpointGroupOrders))
+	assert.False(t, limiter.Allow(EndpointGroupOrders))
+
+	// Groups without a limit are never paced
+	assert.True(t, limiter.Allow(EndpointGroupData))
+
+	states := limiter.State()
+	assert.Len(t, states, 1)
+	assert.Equal(t, EndpointGroupOrders, states[0].Group)
+	assert.Equal(t, 2, states[0].Burst)
+	assert.InDelta(t, 0, states[0].Tokens, 1e-9)
+	assert.Equal(t, uint64(2), states[0].Throttled)
+}
+
+func TestRateLimiterWaitBlocks(t *testing.T) {
+	limiter := NewRateLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupBooks: {Rate: 20, Burst: 1},
+	})
+
+	ctx := context.Background()
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(ctx, EndpointGroupBooks))
+	assert.NoError(t, limiter.Wait(ctx, EndpointGroupBooks))
+	assert.NoError(t, limiter.Wait(ctx, EndpointGroupBooks))
+
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestRateLimiterWaitFailsFastBeforeDeadline(t *testing.T) {
+	limiter := NewRateLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupOrders: {Rate: 1, Burst: 1},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, limiter.Wait(ctx, EndpointGroupOrders))
+
+	start := time.Now()
+	err := limiter.Wait(ctx, EndpointGroupOrders)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestRateLimiterWaitWithZeroRate(t *testing.T) {
+	limiter := NewRateLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupAuth: {Rate: 0, Burst: 1},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	assert.NoError(t, limiter.Wait(ctx, EndpointGroupAuth))
+
+	// The bucket is spent for good, with or without a deadline
+	start := time.Now()
+	assert.ErrorIs(t, limiter.Wait(ctx, EndpointGroupAuth), ErrRateLimited)
+	assert.ErrorIs(t, limiter.Wait(context.Background(), EndpointGroupAuth), ErrRateLimited)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, 0, limiter.State()[0].Waiting)
+}
+
+func TestRateLimiterWaitReturnsReservationOnCancel(t *testing.T) {
+	limiter := NewRateLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupCancels: {Rate: 1, Burst: 1},
+	})
+	assert.True(t, limiter.Allow(EndpointGroupCancels))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err := limiter.Wait(ctx, EndpointGroupCancels)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	states := limiter.State()
+	assert.Equal(t, 0, states[0].Waiting)
+	assert.Less(t, states[0].Tokens, 1.0)
+	assert.Greater(t, states[0].Tokens, -0.5)
+}
+
+func TestRateLimiterWaitRefundCappedAtBurst(t *testing.T) {
+	var now atomic.Int64
+	now.Store(time.Unix(1700000000, 0).UnixNano())
+	limiter := NewRateLimiter(nil)
+	limiter.now = func() time.Time { return time.Unix(0, now.Load()) }
+	limiter.SetLimit(EndpointGroupCancels, RateLimit{Rate: 1, Burst: 1})
+	assert.True(t, limiter.Allow(EndpointGroupCancels))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- limiter.Wait(ctx, EndpointGroupCancels)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return limiter.State()[0].Waiting == 1
+	}, time.Second, time.Millisecond)
+
+	// The bucket refills completely while the waiter sleeps
+	now.Add(int64(10 * time.Second))
+	assert.InDelta(t, 1, limiter.State()[0].Tokens, 1e-9)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.InDelta(t, 1, limiter.State()[0].Tokens, 1e-9)
+}
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected EndpointGroup
+	}{
+		{http.MethodPost, EndpointPostOrder, EndpointGroupOrders},
+		{http.MethodDelete, EndpointCancelOrder, EndpointGroupCancels},
+		{http.MethodDelete, EndpointCancelAll, EndpointGroupCancels},
+		{http.MethodDelete, EndpointCancelMarketOrders, EndpointGroupCancels},
+		{http.MethodGet, EndpointGetOrderBook, EndpointGroupBooks},
+		{http.MethodGet, EndpointGetMidpoint, EndpointGroupBooks},
+		{http.MethodPost, EndpointGetOrderBooks, EndpointGroupBooks},
+		{http.MethodPost, EndpointGetSpreads, EndpointGroupBooks},
+		{http.MethodGet, EndpointDeriveAPIKey, EndpointGroupAuth},
+		{http.MethodGet, EndpointGetTrades, EndpointGroupData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyEndpoint(tt.method, tt.path))
+		})
+	}
+}
+
+func TestHTTPClientUsesRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, "", nil, SignatureTypeEOA, nil)
+	client.SetRateLimiter(NewRateLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupBooks: {Rate: 0.001, Burst: 1},
+	}))
+
+	_, err := client.GetMidpoint("123")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = client.GetMidpointWithContext(ctx, "123")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+}
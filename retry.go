@@ -0,0 +1,132 @@
+package clobclient
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how HTTPClient retries failed requests.
+//
+// Only transport errors (connection resets, timeouts, ...) and responses
+// whose status is listed in RetryableStatuses are retried. Requests that are
+// not idempotent, such as order placement, are never retried unless
+// RetryNonIdempotent is set: a POST that timed out may still have been
+// accepted by the exchange.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles on every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of each delay that is randomized
+	Jitter float64
+	// RetryableStatuses lists the HTTP status codes worth retrying
+	RetryableStatuses []int
+	// RetryNonIdempotent allows retrying non-idempotent requests (e.g. POST /order)
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by new HTTP clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooEarly,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNonIdempotent: false,
+	}
+}
+
+// Backoff returns the delay before retry number attempt (0-based). A
+// server-provided Retry-After longer than the computed delay takes precedence.
+func (p RetryPolicy) Backoff(attempt int, retryAfter time.Duration) time.Duration {
+	// Double until the cap, if any, without overflowing
+	delay := p.BaseDelay
+	for i := 0; i < attempt && delay > 0 && delay <= math.MaxInt64/2; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	if retryAfter > delay {
+		return retryAfter
+	}
+
+	return delay
+}
+
+// isRetryable reports whether err is worth retrying under this policy
+func (p RetryPolicy) isRetryable(err error, idempotent bool) bool {
+	if !idempotent && !p.RetryNonIdempotent {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, status := range p.RetryableStatuses {
+			if apiErr.StatusCode == status {
+				return true
+			}
+		}
+		return false
+	}
+
+	var transportErr *transportError
+	return errors.As(err, &transportErr)
+}
+
+// transportError marks failures to reach the server or read its response
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return "request failed: " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isIdempotentMethod reports whether repeating a request with this method is safe
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the Retry-After delay carried by err, if any
+func retryAfter(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RateLimit.RetryAfter
+	}
+	return 0
+}
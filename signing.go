@@ -101,13 +101,67 @@ func BuildClobEip712Signature(
 	return hexutil.Encode(signature), nil
 }
 
-// BuildOrderSignature creates an EIP712 signature for an order
+// BuildOrderSignature creates an EIP712 signature for an order on the standard CTF Exchange
 func BuildOrderSignature(
 	chainID int,
 	privateKey string,
 	order *SignedOrder,
 	signatureType SignatureType,
 ) (string, error) {
+	return BuildOrderSignatureForExchange(chainID, privateKey, order, signatureType, false)
+}
+
+// BuildOrderSignatureForExchange creates an EIP712 signature for an order,
+// using the Neg Risk CTF Exchange as verifying contract when negRisk is set
+func BuildOrderSignatureForExchange(
+	chainID int,
+	privateKey string,
+	order *SignedOrder,
+	signatureType SignatureType,
+	negRisk bool,
+) (string, error) {
+	hash, err := hashOrder(chainID, order, signatureType, negRisk)
+	if err != nil {
+		return "", err
+	}
+
+	// Get private key
+	privateKeyBytes, err := hexutil.Decode(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+
+	key, err := crypto.ToECDSA(privateKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	// Sign the hash
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %w", err)
+	}
+
+	// Adjust V value
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+
+	return hexutil.Encode(signature), nil
+}
+
+// hashOrder returns the EIP712 digest of an order for the exchange it settles on
+func hashOrder(
+	chainID int,
+	order *SignedOrder,
+	signatureType SignatureType,
+	negRisk bool,
+) ([]byte, error) {
+	exchangeAddress, err := getExchangeAddress(chainID, negRisk)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the typed data for order signing
 	typedData := apitypes.TypedData{
 		Types: apitypes.Types{
@@ -137,7 +191,7 @@ func BuildOrderSignature(
 			Name:              "Polymarket CTF Exchange",
 			Version:           "1",
 			ChainId:           (*math.HexOrDecimal256)(big.NewInt(int64(chainID))),
-			VerifyingContract: getExchangeAddress(chainID),
+			VerifyingContract: exchangeAddress,
 		},
 		Message: apitypes.TypedDataMessage{},
 	}
@@ -164,58 +218,37 @@ func BuildOrderSignature(
 	typedData.Message["side"] = fmt.Sprintf("%d", sideValue)
 	typedData.Message["signatureType"] = fmt.Sprintf("%d", signatureType)
 
-	// Get private key
-	privateKeyBytes, err := hexutil.Decode(privateKey)
-	if err != nil {
-		return "", fmt.Errorf("invalid private key: %w", err)
-	}
-
-	key, err := crypto.ToECDSA(privateKeyBytes)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse private key: %w", err)
-	}
-
 	// Hash the typed data
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
-		return "", fmt.Errorf("failed to hash domain: %w", err)
+		return nil, fmt.Errorf("failed to hash domain: %w", err)
 	}
 
 	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
 	if err != nil {
-		return "", fmt.Errorf("failed to hash message: %w", err)
+		return nil, fmt.Errorf("failed to hash message: %w", err)
 	}
 
 	// Create the final hash
 	rawData := []byte{0x19, 0x01}
 	rawData = append(rawData, domainSeparator...)
 	rawData = append(rawData, messageHash...)
-	hash := crypto.Keccak256(rawData)
 
-	// Sign the hash
-	signature, err := crypto.Sign(hash, key)
+	return crypto.Keccak256(rawData), nil
+}
+
+// getExchangeAddress returns the exchange contract address for a given chain
+func getExchangeAddress(chainID int, negRisk bool) (string, error) {
+	config, err := GetContractConfig(chainID)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign: %w", err)
+		return "", err
 	}
 
-	// Adjust V value
-	if signature[64] < 27 {
-		signature[64] += 27
+	if negRisk {
+		return config.NegRiskExchange, nil
 	}
 
-	return hexutil.Encode(signature), nil
-}
-
-// getExchangeAddress returns the exchange contract address for a given chain
-func getExchangeAddress(chainID int) string {
-	switch chainID {
-	case 137: // Polygon
-		return "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
-	case 80002: // Amoy
-		return "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"
-	default:
-		return "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
-	}
+	return config.Exchange, nil
 }
 
 // BuildPolyHmacSignature creates an HMAC signature for API authentication
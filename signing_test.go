@@ -0,0 +1,114 @@
+package clobclient
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func testOrder(t *testing.T) *SignedOrder {
+	address, err := GetAddressFromPrivateKey(testPrivateKey)
+	assert.NoError(t, err)
+
+	return &SignedOrder{
+		Salt:          12345,
+		Maker:         address,
+		Signer:        address,
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "1234",
+		MakerAmount:   "5200000",
+		TakerAmount:   "10000000",
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "0",
+		Side:          SideBuy,
+		SignatureType: SignatureTypeEOA,
+	}
+}
+
+// recoverOrderSigner returns the address that signed order for the given exchange
+func recoverOrderSigner(t *testing.T, chainID int, order *SignedOrder, signature string, negRisk bool) string {
+	hash, err := hashOrder(chainID, order, order.SignatureType, negRisk)
+	assert.NoError(t, err)
+
+	sig, err := hexutil.Decode(signature)
+	assert.NoError(t, err)
+	sig[64] -= 27
+
+	pub, err := crypto.SigToPub(hash, sig)
+	assert.NoError(t, err)
+
+	return crypto.PubkeyToAddress(*pub).Hex()
+}
+
+func TestBuildOrderSignatureSelectsExchange(t *testing.T) {
+	for _, chainID := range []int{int(ChainPolygon), int(ChainAmoy)} {
+		order := testOrder(t)
+
+		standard, err := BuildOrderSignature(chainID, testPrivateKey, order, SignatureTypeEOA)
+		assert.NoError(t, err)
+
+		negRisk, err := BuildOrderSignatureForExchange(chainID, testPrivateKey, order, SignatureTypeEOA, true)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, standard, negRisk)
+		assert.Equal(t, order.Signer, recoverOrderSigner(t, chainID, order, standard, false))
+		assert.Equal(t, order.Signer, recoverOrderSigner(t, chainID, order, negRisk, true))
+		assert.NotEqual(t, order.Signer, recoverOrderSigner(t, chainID, order, standard, true))
+	}
+}
+
+func TestOrderBuilderSignsNegRiskOrders(t *testing.T) {
+	builder := NewOrderBuilder(testPrivateKey, int(ChainPolygon), SignatureTypeEOA, nil)
+	negRisk := true
+
+	order, err := builder.BuildOrder(
+		&UserOrder{TokenID: "1234", Price: 0.5, Size: 10, Side: SideBuy},
+		&CreateOrderOptions{TickSize: TickSize001, NegRisk: &negRisk},
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, order.Signer, recoverOrderSigner(t, int(ChainPolygon), order, order.Signature, true))
+}
+
+func TestContractConfigRegistry(t *testing.T) {
+	// Restore the registry, so that the test can run again
+	contractConfigsMu.Lock()
+	saved := make(map[int]ContractConfig, len(contractConfigs))
+	for chainID, config := range contractConfigs {
+		saved[chainID] = config
+	}
+	contractConfigsMu.Unlock()
+	t.Cleanup(func() {
+		contractConfigsMu.Lock()
+		defer contractConfigsMu.Unlock()
+		contractConfigs = saved
+	})
+
+	config, err := GetContractConfig(int(ChainPolygon))
+	assert.NoError(t, err)
+	assert.Equal(t, "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E", config.Exchange)
+	assert.Equal(t, "0xC5d563A36AE78145C45a50134d48A1215220f80a", config.NegRiskExchange)
+
+	_, err = GetContractConfig(31337)
+	assert.Error(t, err)
+
+	_, err = BuildOrderSignature(31337, testPrivateKey, testOrder(t), SignatureTypeEOA)
+	assert.Error(t, err)
+
+	SetContractConfig(31337, ContractConfig{
+		Exchange:        "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		NegRiskExchange: "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512",
+	})
+
+	exchange, err := getExchangeAddress(31337, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512", exchange)
+
+	order := testOrder(t)
+	signature, err := BuildOrderSignatureForExchange(31337, testPrivateKey, order, SignatureTypeEOA, false)
+	assert.NoError(t, err)
+	assert.Equal(t, order.Signer, recoverOrderSigner(t, 31337, order, signature, false))
+}
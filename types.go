@@ -1,6 +1,12 @@
 package clobclient
 
-import "time"
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Side represents the order side
 type Side string
@@ -20,6 +26,48 @@ const (
 	OrderTypeFAK OrderType = "FAK" // Fill and Kill
 )
 
+// OrderStatus represents the status of an order
+type OrderStatus string
+
+const (
+	OrderStatusLive      OrderStatus = "LIVE"      // Resting on the book
+	OrderStatusMatched   OrderStatus = "MATCHED"   // Fully matched
+	OrderStatusCanceled  OrderStatus = "CANCELED"  // Canceled before being fully matched
+	OrderStatusDelayed   OrderStatus = "DELAYED"   // Marketable, matching is delayed
+	OrderStatusUnmatched OrderStatus = "UNMATCHED" // Marketable, placed on the book after the delay
+)
+
+// UnmarshalJSON accepts statuses in any case, since some endpoints report them in lowercase
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	var status string
+	if err := json.Unmarshal(data, &status); err != nil {
+		return err
+	}
+	*s = OrderStatus(strings.ToUpper(status))
+	return nil
+}
+
+// IsOpen reports whether the order can still be matched
+func (s OrderStatus) IsOpen() bool {
+	return s == OrderStatusLive || s == OrderStatusDelayed || s == OrderStatusUnmatched
+}
+
+// TradeStatus represents the settlement status of a trade
+type TradeStatus string
+
+const (
+	TradeStatusMatched   TradeStatus = "MATCHED"   // Matched, not yet sent on-chain
+	TradeStatusMined     TradeStatus = "MINED"     // Included in a block
+	TradeStatusConfirmed TradeStatus = "CONFIRMED" // Final
+	TradeStatusRetrying  TradeStatus = "RETRYING"  // Transaction failed, being resubmitted
+	TradeStatusFailed    TradeStatus = "FAILED"    // Failed permanently
+)
+
+// IsFinal reports whether the trade will not change status anymore
+func (s TradeStatus) IsFinal() bool {
+	return s == TradeStatusConfirmed || s == TradeStatusFailed
+}
+
 // Chain represents the blockchain network
 type Chain int
 
@@ -140,6 +188,14 @@ type PostOrderArgs struct {
 	PostOnly  *bool       `json:"postOnly,omitempty"`
 }
 
+// BatchOrder represents one order of a CreateAndPostOrders batch
+type BatchOrder struct {
+	Order     *UserOrder          `json:"order"`
+	Options   *CreateOrderOptions `json:"options,omitempty"`
+	OrderType OrderType           `json:"orderType"`
+	PostOnly  *bool               `json:"postOnly,omitempty"`
+}
+
 // OrderPayload represents the order ID payload
 type OrderPayload struct {
 	OrderID string `json:"orderID"`
@@ -161,28 +217,94 @@ type OrderResponse struct {
 	ErrorMsg           string   `json:"errorMsg"`
 	OrderID            string   `json:"orderID"`
 	TransactionsHashes []string `json:"transactionsHashes"`
-	Status             string   `json:"status"`
-	TakingAmount       string   `json:"takingAmount"`
-	MakingAmount       string   `json:"makingAmount"`
+	// Status is LIVE, MATCHED, DELAYED or UNMATCHED
+	Status OrderStatus `json:"status"`
+	// TakingAmount and MakingAmount are what the order received and gave up
+	// when it matched on placement; see Fill
+	TakingAmount string `json:"takingAmount"`
+	MakingAmount string `json:"makingAmount"`
+}
+
+// Err returns an *OrderError describing why the order was rejected, or nil
+// if it was accepted
+func (r *OrderResponse) Err() error {
+	if r.Success {
+		return nil
+	}
+	return &OrderError{
+		Reason:  orderRejectionReason(r.ErrorMsg),
+		Message: r.ErrorMsg,
+		OrderID: r.OrderID,
+	}
+}
+
+// CancelResponse represents the response from canceling orders
+type CancelResponse struct {
+	// Canceled holds the IDs of the orders that were canceled
+	Canceled []string `json:"canceled"`
+	// NotCanceled maps the IDs of the orders that could not be canceled to the reason
+	NotCanceled map[string]string `json:"not_canceled"`
+}
+
+// merge adds the results of other to r
+func (r *CancelResponse) merge(other *CancelResponse) {
+	r.Canceled = append(r.Canceled, other.Canceled...)
+	for id, reason := range other.NotCanceled {
+		r.NotCanceled[id] = reason
+	}
+}
+
+// OpenOrder represents an order as returned by GetOrder and GetOpenOrders
+type OpenOrder struct {
+	ID              string      `json:"id"`
+	Status          OrderStatus `json:"status"`
+	Owner           string      `json:"owner"`
+	MakerAddress    string      `json:"maker_address"`
+	Market          string      `json:"market"`
+	AssetID         string      `json:"asset_id"`
+	Side            Side        `json:"side"`
+	OriginalSize    string      `json:"original_size"`
+	SizeMatched     string      `json:"size_matched"`
+	Price           string      `json:"price"`
+	AssociateTrades []string    `json:"associate_trades"`
+	Outcome         string      `json:"outcome"`
+	CreatedAt       int64       `json:"created_at"`
+	Expiration      string      `json:"expiration"`
+	OrderType       OrderType   `json:"order_type"`
 }
 
-// OpenOrder represents an open order
-type OpenOrder struct {
-	ID              string   `json:"id"`
-	Status          string   `json:"status"`
-	Owner           string   `json:"owner"`
-	MakerAddress    string   `json:"maker_address"`
-	Market          string   `json:"market"`
-	AssetID         string   `json:"asset_id"`
-	Side            string   `json:"side"`
-	OriginalSize    string   `json:"original_size"`
-	SizeMatched     string   `json:"size_matched"`
-	Price           string   `json:"price"`
-	AssociateTrades []string `json:"associate_trades"`
-	Outcome         string   `json:"outcome"`
-	CreatedAt       int64    `json:"created_at"`
-	Expiration      string   `json:"expiration"`
-	OrderType       string   `json:"order_type"`
+// OriginalSizeValue returns the original size of the order in shares
+func (o *OpenOrder) OriginalSizeValue() (float64, error) {
+	return strconv.ParseFloat(o.OriginalSize, 64)
+}
+
+// SizeMatchedValue returns the number of shares matched so far
+func (o *OpenOrder) SizeMatchedValue() (float64, error) {
+	return strconv.ParseFloat(o.SizeMatched, 64)
+}
+
+// PriceValue returns the limit price of the order
+func (o *OpenOrder) PriceValue() (float64, error) {
+	return strconv.ParseFloat(o.Price, 64)
+}
+
+// RemainingSize returns the number of shares still to be matched. The
+// subtraction is exact, so a fully matched order returns exactly zero.
+func (o *OpenOrder) RemainingSize() (float64, error) {
+	original, err := decimalFromString(o.OriginalSize)
+	if err != nil {
+		return 0, err
+	}
+
+	matched := new(big.Rat)
+	if o.SizeMatched != "" {
+		if matched, err = decimalFromString(o.SizeMatched); err != nil {
+			return 0, err
+		}
+	}
+
+	remaining, _ := new(big.Rat).Sub(original, matched).Float64()
+	return remaining, nil
 }
 
 // TradeParams represents parameters for trade queries
@@ -270,6 +392,72 @@ type OrderMarketCancelParams struct {
 	AssetID *string `json:"asset_id,omitempty"`
 }
 
+// Market represents a market of the CLOB
+type Market struct {
+	ConditionID             string   `json:"condition_id"`
+	QuestionID              string   `json:"question_id"`
+	Question                string   `json:"question"`
+	Description             string   `json:"description"`
+	MarketSlug              string   `json:"market_slug"`
+	Category                string   `json:"category"`
+	Tags                    []string `json:"tags"`
+	Icon                    string   `json:"icon"`
+	Image                   string   `json:"image"`
+	Tokens                  []Token  `json:"tokens"`
+	Rewards                 Rewards  `json:"rewards"`
+	MinimumOrderSize        float64  `json:"minimum_order_size"`
+	MinimumTickSize         float64  `json:"minimum_tick_size"`
+	MakerBaseFee            float64  `json:"maker_base_fee"`
+	TakerBaseFee            float64  `json:"taker_base_fee"`
+	EndDateISO              string   `json:"end_date_iso"`
+	GameStartTime           string   `json:"game_start_time"`
+	SecondsDelay            int      `json:"seconds_delay"`
+	FPMM                    string   `json:"fpmm"`
+	Active                  bool     `json:"active"`
+	Closed                  bool     `json:"closed"`
+	Archived                bool     `json:"archived"`
+	AcceptingOrders         bool     `json:"accepting_orders"`
+	AcceptingOrderTimestamp string   `json:"accepting_order_timestamp"`
+	EnableOrderBook         bool     `json:"enable_order_book"`
+	NegRisk                 bool     `json:"neg_risk"`
+	NegRiskMarketID         string   `json:"neg_risk_market_id"`
+	NegRiskRequestID        string   `json:"neg_risk_request_id"`
+	Is5050Outcome           bool     `json:"is_50_50_outcome"`
+	NotificationsEnabled    bool     `json:"notifications_enabled"`
+}
+
+// SimplifiedMarket is the reduced market representation returned by the simplified market lists
+type SimplifiedMarket struct {
+	ConditionID     string  `json:"condition_id"`
+	Tokens          []Token `json:"tokens"`
+	Rewards         Rewards `json:"rewards"`
+	Active          bool    `json:"active"`
+	Closed          bool    `json:"closed"`
+	Archived        bool    `json:"archived"`
+	AcceptingOrders bool    `json:"accepting_orders"`
+}
+
+// Token represents one outcome token of a market
+type Token struct {
+	TokenID string  `json:"token_id"`
+	Outcome string  `json:"outcome"`
+	Price   float64 `json:"price"`
+	Winner  bool    `json:"winner"`
+}
+
+// Rewards represents the liquidity reward parameters of a market
+type Rewards struct {
+	Rates     []RewardRate `json:"rates"`
+	MinSize   float64      `json:"min_size"`
+	MaxSpread float64      `json:"max_spread"`
+}
+
+// RewardRate represents the daily reward rate paid in an asset
+type RewardRate struct {
+	AssetAddress     string  `json:"asset_address"`
+	RewardsDailyRate float64 `json:"rewards_daily_rate"`
+}
+
 // OrderBookSummary represents an order book summary
 type OrderBookSummary struct {
 	Market         string         `json:"market"`
@@ -278,10 +466,12 @@ type OrderBookSummary struct {
 	Bids           []OrderSummary `json:"bids"`
 	Asks           []OrderSummary `json:"asks"`
 	MinOrderSize   string         `json:"min_order_size"`
-	TickSize       string         `json:"tick_size"`
+	TickSize       TickSize       `json:"tick_size"`
 	NegRisk        bool           `json:"neg_risk"`
 	LastTradePrice string         `json:"last_trade_price"`
-	Hash           string         `json:"hash"`
+	// Hash is the server's hash of the book. The serialization it covers is
+	// not documented, so it is passed through as is rather than verified.
+	Hash string `json:"hash"`
 }
 
 // OrderSummary represents a price level in the order book
@@ -371,9 +561,21 @@ type MarketTradeEvent struct {
 // BookParams represents parameters for order book queries
 type BookParams struct {
 	TokenID string `json:"token_id"`
+	Side    Side   `json:"side,omitempty"`
+}
+
+// LastTradePrice represents the price and side of the last trade of a token
+type LastTradePrice struct {
+	TokenID string `json:"token_id"`
+	Price   string `json:"price"`
 	Side    Side   `json:"side"`
 }
 
+// PriceValue returns the price of the last trade
+func (p *LastTradePrice) PriceValue() (float64, error) {
+	return strconv.ParseFloat(p.Price, 64)
+}
+
 // BuilderApiKey represents builder API key credentials
 type BuilderApiKey struct {
 	Key        string `json:"key"`
@@ -0,0 +1,447 @@
+package clobclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// User channel event types
+const (
+	EventTypeOrder = "order"
+	EventTypeTrade = "trade"
+)
+
+// OrderEventType tells what happened to an order
+type OrderEventType string
+
+const (
+	OrderEventPlacement    OrderEventType = "PLACEMENT"    // The order was placed
+	OrderEventUpdate       OrderEventType = "UPDATE"       // Part of the order was matched
+	OrderEventCancellation OrderEventType = "CANCELLATION" // The order was canceled
+)
+
+// UserEvent is an event of the user channel: *OrderEvent or *TradeEvent, or
+// *DisconnectedEvent and *ResyncedEvent around a reconnection
+type UserEvent interface {
+	userEvent()
+}
+
+// OrderEvent reports a change to one of the user's orders
+type OrderEvent struct {
+	EventType       string         `json:"event_type"`
+	Type            OrderEventType `json:"type"`
+	ID              string         `json:"id"`
+	Market          string         `json:"market"`
+	AssetID         string         `json:"asset_id"`
+	Side            Side           `json:"side"`
+	Price           string         `json:"price"`
+	OriginalSize    string         `json:"original_size"`
+	SizeMatched     string         `json:"size_matched"`
+	Outcome         string         `json:"outcome"`
+	Owner           string         `json:"owner"`
+	OrderOwner      string         `json:"order_owner"`
+	AssociateTrades []string       `json:"associate_trades"`
+	Timestamp       string         `json:"timestamp"`
+}
+
+// TradeEvent reports a trade involving one of the user's orders, first when
+// it is matched and again on each settlement status change
+type TradeEvent struct {
+	EventType    string       `json:"event_type"`
+	ID           string       `json:"id"`
+	Status       TradeStatus  `json:"status"`
+	Market       string       `json:"market"`
+	AssetID      string       `json:"asset_id"`
+	Side         Side         `json:"side"`
+	Price        string       `json:"price"`
+	Size         string       `json:"size"`
+	Outcome      string       `json:"outcome"`
+	Owner        string       `json:"owner"`
+	TradeOwner   string       `json:"trade_owner"`
+	TakerOrderID string       `json:"taker_order_id"`
+	MakerOrders  []MakerOrder `json:"maker_orders"`
+	MatchTime    string       `json:"matchtime"`
+	LastUpdate   string       `json:"last_update"`
+	Timestamp    string       `json:"timestamp"`
+	// Backfilled is set on trades fetched through GetTrades after a reconnection
+	Backfilled bool `json:"-"`
+}
+
+// OrderIDs returns the IDs of the taker order and of every maker order of the trade
+func (e *TradeEvent) OrderIDs() []string {
+	ids := []string{e.TakerOrderID}
+	for _, maker := range e.MakerOrders {
+		ids = append(ids, maker.OrderID)
+	}
+	return ids
+}
+
+func (*OrderEvent) userEvent() {}
+func (*TradeEvent) userEvent() {}
+
+// decodeUserEvent decodes a single user channel event. Unknown event types
+// are returned as nil so that new server events don't break the stream.
+func decodeUserEvent(raw json.RawMessage) (UserEvent, error) {
+	eventType, err := decodeEventType(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var event UserEvent
+	switch eventType {
+	case EventTypeOrder:
+		event = &OrderEvent{}
+	case EventTypeTrade:
+		event = &TradeEvent{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(raw, event); err != nil {
+		return nil, fmt.Errorf("failed to parse %s event: %w", eventType, err)
+	}
+	return event, nil
+}
+
+// eventMarket returns the market of a user event
+func eventMarket(event UserEvent) string {
+	switch e := event.(type) {
+	case *OrderEvent:
+		return e.Market
+	case *TradeEvent:
+		return e.Market
+	}
+	return ""
+}
+
+// UserHandlers are the callbacks Run invokes for each event. Nil handlers are skipped.
+type UserHandlers struct {
+	OnOrder        func(*OrderEvent)
+	OnTrade        func(*TradeEvent)
+	OnDisconnected func(*DisconnectedEvent)
+	OnResynced     func(*ResyncedEvent)
+}
+
+// UserStream streams the lifecycle of the user's orders and trades over the
+// authenticated user channel.
+//
+// Events are delivered one at a time in the order the server sent them, so
+// all events of an order ID, including the trades it takes part in, arrive
+// in order.
+//
+// The stream keeps itself alive: it sends heartbeats and, when the connection
+// drops, delivers a *DisconnectedEvent, reconnects with backoff, resubscribes,
+// backfills the trades made since the last one seen through GetTrades and
+// delivers a *ResyncedEvent. Backfilled trades may repeat a status that was
+// already delivered.
+type UserStream struct {
+	client  *ClobClient
+	session *wsSession
+
+	mu      sync.Mutex
+	markets map[string]bool
+	// lastTrade is the match time, in Unix seconds, of the latest trade seen
+	lastTrade int64
+
+	events     chan UserEvent
+	finishOnce sync.Once
+}
+
+// NewUserStream creates a user channel stream authenticated with the
+// credentials of client, which also backfills trades after a reconnection.
+// url defaults to WSUserURL.
+func NewUserStream(url string, client *ClobClient) *UserStream {
+	if url == "" {
+		url = WSUserURL
+	}
+
+	s := &UserStream{
+		client:  client,
+		markets: make(map[string]bool),
+		events:  make(chan UserEvent, 256),
+	}
+	s.session = newWSSession("user stream", url, s)
+	return s
+}
+
+// SetReconnectPolicy configures how the stream reconnects. It must be called
+// before Connect.
+func (s *UserStream) SetReconnectPolicy(policy ReconnectPolicy) {
+	s.session.reconnect = policy
+}
+
+// SetPingInterval configures how often heartbeats are sent; zero disables
+// them. It must be called before Connect.
+func (s *UserStream) SetPingInterval(interval time.Duration) {
+	s.session.pingInterval = interval
+}
+
+// Connect opens the connection and subscribes to the markets added with
+// Subscribe. Without markets, events of every market are delivered.
+func (s *UserStream) Connect() error {
+	return s.ConnectWithContext(context.Background())
+}
+
+// ConnectWithContext is like Connect but bound to ctx. ctx only bounds the
+// first handshake; use Close to end the stream.
+func (s *UserStream) ConnectWithContext(ctx context.Context) error {
+	if s.client == nil || s.client.Credentials() == nil {
+		return fmt.Errorf("API credentials required for the user channel")
+	}
+	return s.session.connect(ctx)
+}
+
+// subscription returns the message authenticating a connection and
+// subscribing it to every market. Credentials are read on every connection,
+// so rotated credentials are picked up on reconnect.
+func (s *UserStream) subscription() (interface{}, error) {
+	creds := s.client.Credentials()
+	if creds == nil {
+		return nil, fmt.Errorf("API credentials required for the user channel")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return map[string]interface{}{
+		"auth": map[string]string{
+			"apiKey":     creds.Key,
+			"secret":     creds.Secret,
+			"passphrase": creds.Passphrase,
+		},
+		"markets": s.marketIDs(),
+		"type":    "user",
+	}, nil
+}
+
+// marketIDs returns the subscribed markets in a stable order. Callers must hold mu.
+func (s *UserStream) marketIDs() []string {
+	ids := make([]string, 0, len(s.markets))
+	for id := range s.markets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Subscribe adds markets (condition IDs) to the stream, sending the
+// subscription right away if the stream is connected
+func (s *UserStream) Subscribe(markets ...string) error {
+	return s.session.update(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, id := range markets {
+			s.markets[id] = true
+		}
+	}, map[string]interface{}{
+		"markets":   markets,
+		"operation": "subscribe",
+	})
+}
+
+// Unsubscribe removes markets from the stream. Events of these markets still
+// in flight are dropped.
+func (s *UserStream) Unsubscribe(markets ...string) error {
+	return s.session.update(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, id := range markets {
+			delete(s.markets, id)
+		}
+	}, map[string]interface{}{
+		"markets":   markets,
+		"operation": "unsubscribe",
+	})
+}
+
+// Events returns the channel events are delivered on, in the order they were
+// received. It is closed when the stream ends; Err tells why.
+func (s *UserStream) Events() <-chan UserEvent {
+	return s.events
+}
+
+// Run invokes handlers for each event until the stream ends, and returns the
+// error that ended it. Handlers are called from a single goroutine, in the
+// order events were received.
+func (s *UserStream) Run(handlers UserHandlers) error {
+	for event := range s.events {
+		switch e := event.(type) {
+		case *OrderEvent:
+			if handlers.OnOrder != nil {
+				handlers.OnOrder(e)
+			}
+		case *TradeEvent:
+			if handlers.OnTrade != nil {
+				handlers.OnTrade(e)
+			}
+		case *DisconnectedEvent:
+			if handlers.OnDisconnected != nil {
+				handlers.OnDisconnected(e)
+			}
+		case *ResyncedEvent:
+			if handlers.OnResynced != nil {
+				handlers.OnResynced(e)
+			}
+		}
+	}
+	return s.Err()
+}
+
+// Err returns the error that ended the stream, or nil if it was closed
+func (s *UserStream) Err() error {
+	return s.session.Err()
+}
+
+// Close ends the stream
+func (s *UserStream) Close() error {
+	return s.session.close()
+}
+
+// finish closes the events channel once the stream has ended. It may be
+// reached from both Close and the connection, so it only acts once.
+func (s *UserStream) finish() {
+	s.finishOnce.Do(func() { close(s.events) })
+}
+
+// disconnected tells the consumer the connection dropped
+func (s *UserStream) disconnected(err error) bool {
+	return s.deliver(&DisconnectedEvent{Err: err})
+}
+
+// resynced delivers the trades missed since the last one seen, or since the
+// last message of the dropped connection if none was seen yet
+func (s *UserStream) resynced(since time.Time) bool {
+	trades, err := s.backfill(since)
+	for _, trade := range trades {
+		if !s.deliver(trade) {
+			return false
+		}
+	}
+
+	return s.deliver(&ResyncedEvent{Downtime: time.Since(since), Err: err})
+}
+
+// backfill fetches the trades of the subscribed markets matched after the
+// last trade seen, oldest first
+func (s *UserStream) backfill(since time.Time) ([]*TradeEvent, error) {
+	s.mu.Lock()
+	markets := s.marketIDs()
+	after := s.lastTrade
+	s.mu.Unlock()
+
+	if after == 0 {
+		after = since.Unix()
+	}
+	afterParam := strconv.FormatInt(after, 10)
+
+	var trades []Trade
+	if len(markets) == 0 {
+		// No market filter: backfill every market at once
+		markets = []string{""}
+	}
+	for _, market := range markets {
+		params := &TradeParams{After: &afterParam}
+		if market != "" {
+			params.Market = &market
+		}
+
+		marketTrades, err := s.client.GetAllTradesWithContext(s.session.ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to backfill trades: %w", err)
+		}
+		trades = append(trades, marketTrades...)
+	}
+
+	events := make([]*TradeEvent, 0, len(trades))
+	for _, trade := range trades {
+		events = append(events, tradeEventFromTrade(trade))
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return matchTimeOf(events[i]) < matchTimeOf(events[j])
+	})
+	return events, nil
+}
+
+// tradeEventFromTrade converts a trade returned by GetTrades into a backfilled event
+func tradeEventFromTrade(trade Trade) *TradeEvent {
+	return &TradeEvent{
+		EventType:    EventTypeTrade,
+		ID:           trade.ID,
+		Status:       TradeStatus(strings.ToUpper(trade.Status)),
+		Market:       trade.Market,
+		AssetID:      trade.AssetID,
+		Side:         trade.Side,
+		Price:        trade.Price,
+		Size:         trade.Size,
+		Outcome:      trade.Outcome,
+		Owner:        trade.Owner,
+		TakerOrderID: trade.TakerOrderID,
+		MakerOrders:  trade.MakerOrders,
+		MatchTime:    trade.MatchTime,
+		LastUpdate:   trade.LastUpdate,
+		Backfilled:   true,
+	}
+}
+
+// matchTimeOf returns the match time of a trade in Unix seconds, or 0 if unknown
+func matchTimeOf(trade *TradeEvent) int64 {
+	matchTime, err := strconv.ParseInt(trade.MatchTime, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return matchTime
+}
+
+// handleMessage delivers the events of a message that belong to the
+// subscribed markets. It returns false once the stream is closed.
+func (s *UserStream) handleMessage(data []byte) bool {
+	raws, err := splitMessages(data)
+	if err != nil {
+		return true
+	}
+
+	for _, raw := range raws {
+		event, err := decodeUserEvent(raw)
+		if err != nil || event == nil || !s.wants(event) {
+			continue
+		}
+		if !s.deliver(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver sends an event to the consumer, giving up if the stream is closed.
+// It also records the latest trade seen, from where a backfill starts.
+func (s *UserStream) deliver(event UserEvent) bool {
+	if trade, ok := event.(*TradeEvent); ok {
+		s.mu.Lock()
+		if matchTime := matchTimeOf(trade); matchTime > s.lastTrade {
+			s.lastTrade = matchTime
+		}
+		s.mu.Unlock()
+	}
+
+	select {
+	case s.events <- event:
+		return true
+	case <-s.session.done:
+		return false
+	}
+}
+
+// wants reports whether event belongs to a subscribed market
+func (s *UserStream) wants(event UserEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.markets) == 0 || s.markets[eventMarket(event)]
+}
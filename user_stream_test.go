@@ -0,0 +1,238 @@
+package clobclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserStreamDeliversLifecycleInOrder(t *testing.T) {
+	creds := &ApiKeyCreds{Key: "key", Secret: "secret", Passphrase: "passphrase"}
+
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+		assert.Equal(t, "user", subscription["type"])
+		assert.Equal(t, []interface{}{"0xmarket"}, subscription["markets"])
+		assert.Equal(t, map[string]interface{}{"apiKey": "key", "secret": "secret", "passphrase": "passphrase"}, subscription["auth"])
+
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"order","type":"PLACEMENT","id":"o1","market":"0xmarket",`+
+			`"asset_id":"1","side":"BUY","price":"0.5","original_size":"10","size_matched":"0"}`))
+		// Other markets are filtered out
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"order","type":"PLACEMENT","id":"o2","market":"0xother"}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`[`+
+			`{"event_type":"trade","id":"t1","status":"MATCHED","market":"0xmarket","taker_order_id":"o9",`+
+			`"maker_orders":[{"order_id":"o1","matched_amount":"4","price":"0.5"}]},`+
+			`{"event_type":"order","type":"UPDATE","id":"o1","market":"0xmarket","size_matched":"4"},`+
+			`{"event_type":"unknown"}]`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"trade","id":"t1","status":"CONFIRMED","market":"0xmarket"}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"order","type":"CANCELLATION","id":"o1","market":"0xmarket"}`))
+	}
+
+	server, wsURL := newWSServer(t, handle, nil)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, creds, SignatureTypeEOA, nil)
+	stream := NewUserStream(wsURL, client)
+	stream.SetReconnectPolicy(ReconnectPolicy{Disabled: true})
+	assert.NoError(t, stream.Subscribe("0xmarket"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	var seen []string
+	var trade *TradeEvent
+	err := stream.Run(UserHandlers{
+		OnOrder: func(e *OrderEvent) {
+			seen = append(seen, e.ID+" "+string(e.Type))
+		},
+		OnTrade: func(e *TradeEvent) {
+			seen = append(seen, e.ID+" "+string(e.Status))
+			if trade == nil {
+				trade = e
+			}
+		},
+	})
+
+	// The server hung up after the last event
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"o1 PLACEMENT",
+		"t1 MATCHED",
+		"o1 UPDATE",
+		"t1 CONFIRMED",
+		"o1 CANCELLATION",
+	}, seen)
+
+	assert.Equal(t, []string{"o9", "o1"}, trade.OrderIDs())
+	assert.False(t, trade.Status.IsFinal())
+}
+
+func TestUserStreamBackfillsTradesAfterReconnect(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+		assert.Equal(t, []interface{}{"0xmarket"}, subscription["markets"])
+
+		mu.Lock()
+		connections++
+		first := connections == 1
+		mu.Unlock()
+
+		if first {
+			conn.WriteMessage(websocket.TextMessage, []byte(
+				`{"event_type":"trade","id":"t1","status":"MATCHED","market":"0xmarket","matchtime":"1700000000"}`))
+			return
+		}
+		conn.ReadMessage()
+	}
+
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, EndpointGetTrades, r.URL.Path)
+		assert.Equal(t, "1700000000", r.URL.Query().Get("after"))
+		assert.Equal(t, "0xmarket", r.URL.Query().Get("market"))
+
+		json.NewEncoder(w).Encode(Page[Trade]{
+			NextCursor: EndCursor,
+			Data: []Trade{
+				{ID: "t2", Status: "MATCHED", Market: "0xmarket", MatchTime: "1700000050"},
+				{ID: "t1", Status: "confirmed", Market: "0xmarket", MatchTime: "1700000000"},
+			},
+		})
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	stream := NewUserStream(wsURL, client)
+	stream.SetReconnectPolicy(ReconnectPolicy{BaseDelay: 10 * time.Millisecond})
+	assert.NoError(t, stream.Subscribe("0xmarket"))
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	var seen []string
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *TradeEvent:
+			seen = append(seen, fmt.Sprintf("%s %s %v", e.ID, e.Status, e.Backfilled))
+		case *DisconnectedEvent:
+			seen = append(seen, "disconnected")
+		case *ResyncedEvent:
+			assert.NoError(t, e.Err)
+			seen = append(seen, "resynced")
+			stream.Close()
+		}
+	}
+
+	assert.Equal(t, []string{
+		"t1 MATCHED false",
+		"disconnected",
+		"t1 CONFIRMED true",
+		"t2 MATCHED true",
+		"resynced",
+	}, seen)
+	assert.NoError(t, stream.Err())
+}
+
+func TestUserStreamBackfillsFromLastMessage(t *testing.T) {
+	var mu sync.Mutex
+	connections := 0
+	var received int64
+	handle := func(conn *websocket.Conn) {
+		var subscription map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&subscription))
+
+		mu.Lock()
+		connections++
+		first := connections == 1
+		if first {
+			received = time.Now().Unix()
+		}
+		mu.Unlock()
+
+		if first {
+			conn.WriteMessage(websocket.TextMessage, []byte(
+				`{"event_type":"order","id":"o1","type":"PLACEMENT","market":"0xmarket"}`))
+		}
+		// Never answer heartbeats, so that the first connection goes stale
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}
+
+	afters := make(chan int64, 1)
+	rest := func(w http.ResponseWriter, r *http.Request) {
+		after, _ := strconv.ParseInt(r.URL.Query().Get("after"), 10, 64)
+		afters <- after
+		json.NewEncoder(w).Encode(Page[Trade]{NextCursor: EndCursor})
+	}
+
+	server, wsURL := newWSServer(t, handle, rest)
+	defer server.Close()
+
+	client := NewClobClient(server.URL, 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	stream := NewUserStream(wsURL, client)
+	stream.SetReconnectPolicy(ReconnectPolicy{BaseDelay: 10 * time.Millisecond})
+	stream.SetPingInterval(time.Second)
+	assert.NoError(t, stream.Connect())
+	defer stream.Close()
+
+	for event := range stream.Events() {
+		if _, ok := event.(*ResyncedEvent); ok {
+			stream.Close()
+		}
+	}
+
+	// The backfill starts at the last message, not when the stale connection
+	// was detected two heartbeats later
+	mu.Lock()
+	defer mu.Unlock()
+	assert.LessOrEqual(t, <-afters, received+1)
+}
+
+func TestUserStreamRequiresCredentials(t *testing.T) {
+	stream := NewUserStream("", nil)
+	assert.Error(t, stream.Connect())
+
+	client := NewClobClient("http://localhost", 137, testPrivateKey, nil, SignatureTypeEOA, nil)
+	assert.Error(t, NewUserStream("", client).Connect())
+
+	assert.NoError(t, stream.Close())
+	_, open := <-stream.Events()
+	assert.False(t, open)
+}
+
+func TestUserStreamConnectAfterClose(t *testing.T) {
+	client := NewClobClient("http://localhost", 137, testPrivateKey, testCreds(1), SignatureTypeEOA, nil)
+	stream := NewUserStream("ws://127.0.0.1:1/ws", client)
+	assert.NoError(t, stream.Close())
+	assert.Error(t, stream.Connect())
+
+	_, open := <-stream.Events()
+	assert.False(t, open)
+}
+
+func TestDecodeUserEvent(t *testing.T) {
+	event, err := decodeUserEvent([]byte(`{"event_type":"order","type":"UPDATE","id":"o1","associate_trades":["t1"]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &OrderEvent{EventType: EventTypeOrder, Type: OrderEventUpdate, ID: "o1", AssociateTrades: []string{"t1"}}, event)
+
+	event, err = decodeUserEvent([]byte(`{"event_type":"trade","id":"t1","status":"FAILED","matchtime":"1700000000"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &TradeEvent{EventType: EventTypeTrade, ID: "t1", Status: TradeStatusFailed, MatchTime: "1700000000"}, event)
+	assert.True(t, event.(*TradeEvent).Status.IsFinal())
+
+	_, err = decodeUserEvent([]byte(`{"event_type":"order","size_matched":4}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,435 @@
+package clobclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket endpoints
+const (
+	WSMarketURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+	WSUserURL   = "wss://ws-subscriptions-clob.polymarket.com/ws/user"
+)
+
+// DefaultPingInterval is how often streams send a heartbeat. The server drops
+// connections that stay silent for too long.
+const DefaultPingInterval = 10 * time.Second
+
+// ReconnectPolicy controls how streams reconnect after the connection drops
+type ReconnectPolicy struct {
+	// Disabled ends the stream when the connection drops instead of reconnecting
+	Disabled bool
+	// MaxAttempts limits the consecutive reconnection attempts; zero retries
+	// until the stream is closed
+	MaxAttempts int
+	// BaseDelay is the delay before the first attempt; it doubles on every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of each delay that is randomized
+	Jitter float64
+}
+
+// DefaultReconnectPolicy returns the policy new streams reconnect with: they
+// retry with backoff until closed
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		BaseDelay: 500 * time.Millisecond,
+		MaxDelay:  30 * time.Second,
+		Jitter:    0.2,
+	}
+}
+
+// Backoff returns the delay before reconnection attempt number attempt (0-based)
+func (p ReconnectPolicy) Backoff(attempt int) time.Duration {
+	backoff := RetryPolicy{BaseDelay: p.BaseDelay, MaxDelay: p.MaxDelay, Jitter: p.Jitter}
+	return backoff.Backoff(attempt, 0)
+}
+
+// allows reports whether reconnection attempt number attempt (0-based) may be made
+func (p ReconnectPolicy) allows(attempt int) bool {
+	return !p.Disabled && (p.MaxAttempts <= 0 || attempt < p.MaxAttempts)
+}
+
+// DisconnectedEvent is delivered by a stream when its connection drops. The
+// stream reconnects on its own and delivers a ResyncedEvent once it has caught up.
+type DisconnectedEvent struct {
+	Err error
+}
+
+// ResyncedEvent is delivered by a stream once it has reconnected, resubscribed
+// and backfilled what was missed while disconnected
+type ResyncedEvent struct {
+	// Downtime is how long the stream received nothing, from the last message
+	// of the dropped connection until it was back in sync
+	Downtime time.Duration
+	// Err is set when the backfill failed. The stream is connected, but state
+	// missed during the downtime may be incomplete.
+	Err error
+}
+
+func (*DisconnectedEvent) marketEvent() {}
+func (*DisconnectedEvent) userEvent()   {}
+func (*ResyncedEvent) marketEvent()     {}
+func (*ResyncedEvent) userEvent()       {}
+
+// wsConn wraps a WebSocket connection. gorilla/websocket supports a single
+// concurrent writer, so writes are serialized.
+type wsConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// dialWebSocket opens a WebSocket connection to url
+func dialWebSocket(ctx context.Context, url string) (*wsConn, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	return &wsConn{conn: conn}, nil
+}
+
+// writeJSON sends v as a JSON text message
+func (c *wsConn) writeJSON(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(v)
+}
+
+// writeText sends a raw text message
+func (c *wsConn) writeText(text string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(text))
+}
+
+// read blocks until the next message arrives. A positive timeout fails the
+// read if nothing arrives in time.
+func (c *wsConn) read(timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		if err := c.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+	}
+	_, data, err := c.conn.ReadMessage()
+	return data, err
+}
+
+// close closes the underlying connection, unblocking read
+func (c *wsConn) close() error {
+	return c.conn.Close()
+}
+
+// splitMessages splits a WebSocket message into its events. The server sends
+// either a single event object or an array of events.
+func splitMessages(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if data[0] != '[' {
+		return []json.RawMessage{data}, nil
+	}
+
+	var events []json.RawMessage
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	return events, nil
+}
+
+// decodeEventType returns the event_type field of an event
+func decodeEventType(raw json.RawMessage) (string, error) {
+	var header struct {
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return "", fmt.Errorf("failed to parse event: %w", err)
+	}
+	return header.EventType, nil
+}
+
+// isPong reports whether a message is the server's reply to a heartbeat
+func isPong(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "PONG"
+}
+
+// wsHandler is the channel-specific part of a stream
+type wsHandler interface {
+	// subscription returns the message that subscribes a new connection
+	subscription() (interface{}, error)
+	// handleMessage processes a message; it returns false once the stream is closed
+	handleMessage(data []byte) bool
+	// disconnected is called when the connection drops, before reconnecting
+	disconnected(err error) bool
+	// resynced is called once a new connection is subscribed and sends
+	// heartbeats, to backfill what was missed since since, the time the last
+	// message was received. Messages are only read once it returns.
+	resynced(since time.Time) bool
+	// finish closes the events channel
+	finish()
+}
+
+// wsSession keeps a stream connected: it sends heartbeats, reconnects with
+// backoff when the connection drops and resubscribes on every connection.
+type wsSession struct {
+	name         string
+	url          string
+	handler      wsHandler
+	reconnect    ReconnectPolicy
+	pingInterval time.Duration
+
+	// ctx is canceled when the session is closed, aborting backfill requests
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu guards conn and err. Streams update their subscriptions under mu
+	// so that no change is lost while a new connection subscribes.
+	mu      sync.Mutex
+	conn    *wsConn
+	started bool
+	err     error
+	done    chan struct{}
+}
+
+// newWSSession creates a session for handler
+func newWSSession(name string, url string, handler wsHandler) *wsSession {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &wsSession{
+		name:         name,
+		url:          url,
+		handler:      handler,
+		reconnect:    DefaultReconnectPolicy(),
+		pingInterval: DefaultPingInterval,
+		ctx:          ctx,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+}
+
+// connect opens the first connection and starts the session
+func (s *wsSession) connect(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed() {
+		s.mu.Unlock()
+		return fmt.Errorf("%s closed", s.name)
+	}
+	if s.started {
+		s.mu.Unlock()
+		return fmt.Errorf("%s already connected", s.name)
+	}
+	s.started = true
+	s.mu.Unlock()
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.started = false
+		// Close ran meanwhile and left the events channel to us
+		if s.closed() {
+			s.handler.finish()
+		}
+		return err
+	}
+
+	go s.run(conn)
+	return nil
+}
+
+// dial opens a connection and subscribes it
+func (s *wsSession) dial(ctx context.Context) (*wsConn, error) {
+	conn, err := dialWebSocket(ctx, s.url)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		conn.close()
+		return nil, fmt.Errorf("%s closed", s.name)
+	default:
+	}
+
+	subscription, err := s.handler.subscription()
+	if err == nil {
+		err = conn.writeJSON(subscription)
+	}
+	if err != nil {
+		conn.close()
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	s.conn = conn
+	return conn, nil
+}
+
+// update runs change under mu and sends message on the current connection,
+// if any. Without a connection, the next subscription includes the change.
+func (s *wsSession) update(change func(), message interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	change()
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.writeJSON(message)
+}
+
+// run reads from conn, reconnecting whenever the connection drops, until the
+// session is closed or reconnecting fails
+func (s *wsSession) run(conn *wsConn) {
+	defer s.handler.finish()
+
+	var resync func() bool
+	for {
+		lastMessage, err := s.read(conn, resync)
+
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+
+		if s.closed() || !s.handler.disconnected(err) {
+			return
+		}
+
+		conn = s.redial(err)
+		if conn == nil {
+			return
+		}
+
+		// Nothing was received since the last message, even if the dead
+		// connection went unnoticed for a while
+		resync = func() bool { return s.handler.resynced(lastMessage) }
+	}
+}
+
+// read dispatches the messages of conn until it fails or the session is
+// closed, and returns when the last message, heartbeat replies included, was
+// received. resync, if set, runs once heartbeats have started, before the
+// first read.
+func (s *wsSession) read(conn *wsConn, resync func() bool) (time.Time, error) {
+	stop := make(chan struct{})
+	defer close(stop)
+	defer conn.close()
+
+	// Without a reply to two heartbeats in a row, the connection is dead
+	var timeout time.Duration
+	if s.pingInterval > 0 {
+		timeout = 2 * s.pingInterval
+		go s.heartbeat(conn, stop)
+	}
+
+	lastMessage := time.Now()
+	if resync != nil && !resync() {
+		return lastMessage, nil
+	}
+
+	for {
+		data, err := conn.read(timeout)
+		if err != nil {
+			return lastMessage, err
+		}
+		lastMessage = time.Now()
+		if isPong(data) {
+			continue
+		}
+		if !s.handler.handleMessage(data) {
+			return lastMessage, nil
+		}
+	}
+}
+
+// heartbeat pings conn until stop is closed
+func (s *wsSession) heartbeat(conn *wsConn, stop chan struct{}) {
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// A failed write also fails the pending read
+			if err := conn.writeText("PING"); err != nil {
+				return
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// redial reconnects with backoff. It returns nil once the session is closed
+// or the reconnect policy gives up, recording why in err.
+func (s *wsSession) redial(cause error) *wsConn {
+	lastErr := cause
+	for attempt := 0; s.reconnect.allows(attempt); attempt++ {
+		select {
+		case <-time.After(s.reconnect.Backoff(attempt)):
+		case <-s.done:
+			return nil
+		}
+
+		conn, err := s.dial(s.ctx)
+		if err == nil {
+			return conn
+		}
+		lastErr = err
+	}
+
+	s.mu.Lock()
+	s.err = fmt.Errorf("%s: %w", s.name, lastErr)
+	s.mu.Unlock()
+	return nil
+}
+
+// closed reports whether the session was closed
+func (s *wsSession) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Err returns the error that ended the session, or nil if it was closed
+func (s *wsSession) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// close ends the session. If it never started, the events channel is closed
+// right away; otherwise run closes it on its way out.
+func (s *wsSession) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed() {
+		return nil
+	}
+
+	close(s.done)
+	s.cancel()
+
+	if !s.started {
+		s.handler.finish()
+		return nil
+	}
+	if s.conn != nil {
+		return s.conn.close()
+	}
+	return nil
+}